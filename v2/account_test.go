@@ -35,8 +35,7 @@ func TestGetFiatAccounts(t *testing.T) {
 		ts := testutil.GenerateTestServer(t, c.expect.path, c.expect.method, c.expect.body, c.param.jsonResponse)
 		defer ts.Close()
 
-		client, _ := NewClient("apiTokenID", "secret", nil)
-		client.testServer = ts
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		accounts, _ := client.GetFiatAccounts(ctx)
@@ -74,8 +73,7 @@ func TestCreateAFiatAccount(t *testing.T) {
 		ts := testutil.GenerateTestServer(t, c.expect.path, c.expect.method, c.expect.body, c.param.jsonResponse)
 		defer ts.Close()
 
-		client, _ := NewClient("apiTokenID", "secret", nil)
-		client.testServer = ts
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		account, _ := client.CreateAFiatAccount(ctx, c.param.currency)
@@ -112,8 +110,7 @@ func TestGetCryptoAccounts(t *testing.T) {
 		ts := testutil.GenerateTestServer(t, c.expect.path, c.expect.method, c.expect.body, c.param.jsonResponse)
 		defer ts.Close()
 
-		client, _ := NewClient("apiTokenID", "secret", nil)
-		client.testServer = ts
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		accounts, _ := client.GetCryptoAccounts(ctx)
@@ -150,8 +147,7 @@ func TestGetAllAccountBalances(t *testing.T) {
 		ts := testutil.GenerateTestServer(t, c.expect.path, c.expect.method, c.expect.body, c.param.jsonResponse)
 		defer ts.Close()
 
-		client, _ := NewClient("apiTokenID", "secret", nil)
-		client.testServer = ts
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		accountBalances, _ := client.GetAllAccountBalances(ctx)
@@ -4,18 +4,12 @@ import (
 	"context"
 	"fmt"
 	"github.com/sho3imo/quoinex-go-client/v2/models"
-	"strings"
 )
 
 func (c *Client) GetFiatAccounts(ctx context.Context) ([]*models.Account, error) {
 	spath := fmt.Sprintf("/fiat_accounts")
-	res, err := c.sendRequest(ctx, "GET", spath, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
 	var accounts []*models.Account
-	if err := decodeBody(res, &accounts); err != nil {
+	if err := c.sendRequest(ctx, "GetFiatAccounts", "GET", spath, nil, nil, &accounts); err != nil {
 		return nil, err
 	}
 
@@ -23,15 +17,20 @@ func (c *Client) GetFiatAccounts(ctx context.Context) ([]*models.Account, error)
 }
 
 func (c *Client) CreateAFiatAccount(ctx context.Context, currency string) (*models.Account, error) {
+	return c.CreateFiatAccount(ctx, &FiatAccountRequest{Currency: currency})
+}
+
+func (c *Client) CreateFiatAccount(ctx context.Context, req *FiatAccountRequest) (*models.Account, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request is nil")
+	}
 	spath := fmt.Sprintf("/fiat_accounts")
-	body := fmt.Sprintf("{\"currency\":\"%s\"}", currency)
-	res, err := c.sendRequest(ctx, "POST", spath, strings.NewReader(body), nil)
+	body, err := jsonBody(req)
 	if err != nil {
 		return nil, err
 	}
-
 	var account models.Account
-	if err := decodeBody(res, &account); err != nil {
+	if err := c.sendRequest(ctx, "CreateAFiatAccount", "POST", spath, body, nil, &account); err != nil {
 		return nil, err
 	}
 
@@ -40,13 +39,8 @@ func (c *Client) CreateAFiatAccount(ctx context.Context, currency string) (*mode
 
 func (c *Client) GetCryptoAccounts(ctx context.Context) ([]*models.CryptoAccount, error) {
 	spath := fmt.Sprintf("/crypto_accounts")
-	res, err := c.sendRequest(ctx, "GET", spath, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
 	var accounts []*models.CryptoAccount
-	if err := decodeBody(res, &accounts); err != nil {
+	if err := c.sendRequest(ctx, "GetCryptoAccounts", "GET", spath, nil, nil, &accounts); err != nil {
 		return nil, err
 	}
 
@@ -55,13 +49,8 @@ func (c *Client) GetCryptoAccounts(ctx context.Context) ([]*models.CryptoAccount
 
 func (c *Client) GetAllAccountBalances(ctx context.Context) ([]*models.AccountBalance, error) {
 	spath := fmt.Sprintf("/accounts/balance")
-	res, err := c.sendRequest(ctx, "GET", spath, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
 	var accountBalances []*models.AccountBalance
-	if err := decodeBody(res, &accountBalances); err != nil {
+	if err := c.sendRequest(ctx, "GetAllAccountBalances", "GET", spath, nil, nil, &accountBalances); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,94 @@
+package quoinex
+
+import (
+	"context"
+	"github.com/sho3imo/quoinex-go-client/v2/models"
+	"time"
+)
+
+// API is the set of operations Client offers. Depend on it instead of
+// *Client to substitute a fake in tests; the mock package has one.
+type API interface {
+	// accounts
+	GetFiatAccounts(ctx context.Context) ([]*models.Account, error)
+	CreateAFiatAccount(ctx context.Context, currency string) (*models.Account, error)
+	CreateFiatAccount(ctx context.Context, req *FiatAccountRequest) (*models.Account, error)
+	GetCryptoAccounts(ctx context.Context) ([]*models.CryptoAccount, error)
+	GetAllAccountBalances(ctx context.Context) ([]*models.AccountBalance, error)
+
+	// fiat deposits, withdrawals and bank accounts
+	GetFiatDeposits(ctx context.Context, filter FiatDepositFilter) (*models.FiatDeposits, error)
+	FiatDeposits(ctx context.Context, filter FiatDepositFilter) *FiatDepositIterator
+	CreateFiatWithdrawal(ctx context.Context, req *FiatWithdrawalRequest) (*models.FiatWithdrawal, error)
+	GetFiatWithdrawals(ctx context.Context, filter FiatWithdrawalFilter) (*models.FiatWithdrawals, error)
+	FiatWithdrawals(ctx context.Context, filter FiatWithdrawalFilter) *FiatWithdrawalIterator
+	CancelFiatWithdrawal(ctx context.Context, withdrawalID int) (*models.FiatWithdrawal, error)
+	GetBankAccounts(ctx context.Context, filter BankAccountFilter) (*models.BankAccounts, error)
+	BankAccounts(ctx context.Context, filter BankAccountFilter) *BankAccountIterator
+
+	// crypto withdrawals
+	CreateCryptoWithdrawal(ctx context.Context, req *CryptoWithdrawalRequest) (*models.CryptoWithdrawal, error)
+	GetCryptoWithdrawals(ctx context.Context, filter CryptoWithdrawalFilter) (*models.CryptoWithdrawals, error)
+	CryptoWithdrawals(ctx context.Context, filter CryptoWithdrawalFilter) *CryptoWithdrawalIterator
+	CancelCryptoWithdrawal(ctx context.Context, withdrawalID int) (*models.CryptoWithdrawal, error)
+	GetWithdrawalAddresses(ctx context.Context, currency string) ([]*models.WithdrawalAddress, error)
+
+	// assets lending
+	CreateALoanBid(ctx context.Context, quantity models.Decimal, currency string, rate models.Decimal) (*models.LoanBid, error)
+	CreateLoanBid(ctx context.Context, req *LoanBidRequest) (*models.LoanBid, error)
+	GetLoanBids(ctx context.Context, currency string) (*models.LoanBids, error)
+	LoanBids(ctx context.Context, filter LoanBidFilter) *LoanBidIterator
+	CloseLoanBid(ctx context.Context, loanBidID int) (*models.LoanBid, error)
+	GetLoans(ctx context.Context, currency string) (*models.Loans, error)
+	Loans(ctx context.Context, filter LoanFilter) *LoanIterator
+	UpdateALoan(ctx context.Context, loanID int, fundReloaned bool) (*models.Loan, error)
+
+	// products
+	GetInterestRates(ctx context.Context, currency string) (*models.InterestRates, error)
+	GetOrderBook(ctx context.Context, productID int, full bool) (*models.PriceLevels, error)
+	GetProducts(ctx context.Context) ([]*models.Product, error)
+	GetProduct(ctx context.Context, productID int) (*models.Product, error)
+
+	// executions
+	GetExecutionsByTimestamp(ctx context.Context, productID int, limit int, timestamp time.Time) ([]*models.ExecutionsModels, error)
+	GetExecutions(ctx context.Context, productID int, limit int, page int) (*models.Executions, error)
+	GetOwnExecutions(ctx context.Context, productID int) (*models.Executions, error)
+	Executions(ctx context.Context, filter ExecutionFilter) *ExecutionIterator
+	OwnExecutions(ctx context.Context, filter ExecutionFilter) *ExecutionIterator
+	ExecutionHistory(ctx context.Context, productID int, from, to time.Time, opts ...HistoryOption) *ExecutionHistoryIterator
+
+	// orders
+	GetAnOrder(ctx context.Context, orderID int) (*models.Order, error)
+	GetOrderByClientID(ctx context.Context, clientOrderID string) (*models.Order, error)
+	GetOrders(ctx context.Context, productID, withDetails int, fundingCurrency string, status models.OrderStatus) (*models.Orders, error)
+	GetOrdersByFilter(ctx context.Context, filter OrderFilter) (*models.Orders, error)
+	Orders(ctx context.Context, filter OrderFilter) *OrderIterator
+	CreateAnOrder(ctx context.Context, orderType models.OrderType, side models.Side, quantity, price, priceRange models.Decimal, productID int, clientOrderID string) (*models.Order, error)
+	CreateOrder(ctx context.Context, req *CreateOrderRequest) (*models.Order, error)
+	CancelAnOrder(ctx context.Context, orderID int) (*models.Order, error)
+	CancelOrderByClientID(ctx context.Context, clientOrderID string) (*models.Order, error)
+	EditALiveOrder(ctx context.Context, orderID int, quantity, price models.Decimal) (*models.Order, error)
+	EditOrder(ctx context.Context, orderID int, req *EditOrderRequest) (*models.Order, error)
+	EditOrderByClientID(ctx context.Context, clientOrderID string, req *EditOrderRequest) (*models.Order, error)
+	CancelAllOrders(ctx context.Context, filter OrderFilter, opts ...CancelAllOption) (*CancelReport, error)
+	GetAnOrderTrades(ctx context.Context, orderID int) ([]*models.Trade, error)
+
+	// trades
+	GetTrades(ctx context.Context, fundingCurrency string, status models.TradeStatus) (*models.Trades, error)
+	Trades(ctx context.Context, filter TradeFilter) *TradeIterator
+	CloseTrade(ctx context.Context, tradeID int, closedQuantity models.Decimal) (*models.Trade, error)
+	CloseAllTrade(ctx context.Context, side models.TradeSide) ([]*models.Trade, error)
+	UpdateTrade(ctx context.Context, tradeID int, stopLoss, takeProfit models.Decimal) (*models.Trade, error)
+	EditTrade(ctx context.Context, tradeID int, req *EditTradeRequest) (*models.Trade, error)
+	GetTradesLoans(ctx context.Context, tradeID int) ([]*models.Loan, error)
+
+	// trading accounts
+	GetTradingAccounts(ctx context.Context) ([]*models.TradingAccount, error)
+	GetATradingAccount(ctx context.Context, tradingAccountID int) (*models.TradingAccount, error)
+	UpdateLeverageLevel(ctx context.Context, tradeAccountID, leverageLevel int) (*models.TradingAccount, error)
+	EditTradingAccount(ctx context.Context, tradeAccountID int, req *EditTradingAccountRequest) (*models.TradingAccount, error)
+
+	RateLimitBudget() RateLimitBudget
+}
+
+var _ API = (*Client)(nil)
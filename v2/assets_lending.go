@@ -4,28 +4,23 @@ import (
 	"context"
 	"fmt"
 	"github.com/sho3imo/quoinex-go-client/v2/models"
-	"strconv"
-	"strings"
 )
 
-func (c *Client) CreateALoanBid(ctx context.Context, quantity, currency, rate string) (*models.LoanBid, error) {
+func (c *Client) CreateALoanBid(ctx context.Context, quantity models.Decimal, currency string, rate models.Decimal) (*models.LoanBid, error) {
+	return c.CreateLoanBid(ctx, &LoanBidRequest{Quantity: quantity, Currency: currency, Rate: rate})
+}
+
+func (c *Client) CreateLoanBid(ctx context.Context, req *LoanBidRequest) (*models.LoanBid, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request is nil")
+	}
 	spath := fmt.Sprintf("/loan_bids")
-	bodyTemplate :=
-		`{
-			"loan_bid": {
-				"quantity":"%s",
-				"currency":"%s",
-				"rate":"%s"
-			}
-		}`
-	body := fmt.Sprintf(bodyTemplate, quantity, currency, rate)
-	res, err := c.sendRequest(ctx, "POST", spath, strings.NewReader(body), nil)
+	body, err := jsonBody(&loanBidEnvelope{LoanBid: req})
 	if err != nil {
 		return nil, err
 	}
-
 	var loanBid models.LoanBid
-	if err := decodeBody(res, &loanBid); err != nil {
+	if err := c.sendRequest(ctx, "CreateALoanBid", "POST", spath, body, nil, &loanBid); err != nil {
 		return nil, err
 	}
 
@@ -36,28 +31,46 @@ func (c *Client) GetLoanBids(ctx context.Context, currency string) (*models.Loan
 	spath := fmt.Sprintf("/loan_bids")
 	queryParam := &map[string]string{
 		"currency": currency}
-	res, err := c.sendRequest(ctx, "GET", spath, nil, queryParam)
-	if err != nil {
-		return nil, err
-	}
-
 	var loanBids models.LoanBids
-	if err := decodeBody(res, &loanBids); err != nil {
+	if err := c.sendRequest(ctx, "GetLoanBids", "GET", spath, nil, queryParam, &loanBids); err != nil {
 		return nil, err
 	}
 
 	return &loanBids, nil
 }
 
+// LoanBidFilter selects the loan bids walked by LoanBids.
+type LoanBidFilter struct {
+	Currency string
+	ListOptions
+}
+
+// LoanBidIterator walks every loan bid matching a filter, one page at a time.
+type LoanBidIterator = Iterator[*models.LoanBid]
+
+// NewLoanBidIterator returns an iterator that loads each page with fetch.
+func NewLoanBidIterator(ctx context.Context, opts ListOptions, fetch func(ctx context.Context, page int) (*models.LoanBids, error)) *LoanBidIterator {
+	return newPageIterator(ctx, opts, fetch, func(p *models.LoanBids) ([]*models.LoanBid, int) { return p.Models, p.TotalPages })
+}
+
+// LoanBids returns an iterator over every loan bid matching filter.
+func (c *Client) LoanBids(ctx context.Context, filter LoanBidFilter) *LoanBidIterator {
+	return NewLoanBidIterator(ctx, filter.ListOptions, func(ctx context.Context, page int) (*models.LoanBids, error) {
+		queryParam := map[string]string{
+			"currency": filter.Currency}
+		filter.setQuery(queryParam, page)
+		var loanBids models.LoanBids
+		if err := c.sendRequest(ctx, "GetLoanBids", "GET", "/loan_bids", nil, &queryParam, &loanBids); err != nil {
+			return nil, err
+		}
+		return &loanBids, nil
+	})
+}
+
 func (c *Client) CloseLoanBid(ctx context.Context, loanBidID int) (*models.LoanBid, error) {
 	spath := fmt.Sprintf("/loan_bids/%d/close", loanBidID)
-	res, err := c.sendRequest(ctx, "PUT", spath, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
 	var loanBid models.LoanBid
-	if err := decodeBody(res, &loanBid); err != nil {
+	if err := c.sendRequest(ctx, "CloseLoanBid", "PUT", spath, nil, nil, &loanBid); err != nil {
 		return nil, err
 	}
 
@@ -68,35 +81,52 @@ func (c *Client) GetLoans(ctx context.Context, currency string) (*models.Loans,
 	spath := fmt.Sprintf("/loans")
 	queryParam := &map[string]string{
 		"currency": currency}
-	res, err := c.sendRequest(ctx, "GET", spath, nil, queryParam)
-	if err != nil {
-		return nil, err
-	}
-
 	var loans models.Loans
-	if err := decodeBody(res, &loans); err != nil {
+	if err := c.sendRequest(ctx, "GetLoans", "GET", spath, nil, queryParam, &loans); err != nil {
 		return nil, err
 	}
 
 	return &loans, nil
 }
 
+// LoanFilter selects the loans walked by Loans.
+type LoanFilter struct {
+	Currency string
+	ListOptions
+}
+
+// LoanIterator walks every loan matching a filter, one page at a time.
+type LoanIterator = Iterator[*models.Loan]
+
+// NewLoanIterator returns an iterator that loads each page with fetch.
+func NewLoanIterator(ctx context.Context, opts ListOptions, fetch func(ctx context.Context, page int) (*models.Loans, error)) *LoanIterator {
+	return newPageIterator(ctx, opts, fetch, func(p *models.Loans) ([]*models.Loan, int) { return p.Models, p.TotalPages })
+}
+
+// Loans returns an iterator over every loan matching filter.
+func (c *Client) Loans(ctx context.Context, filter LoanFilter) *LoanIterator {
+	return NewLoanIterator(ctx, filter.ListOptions, func(ctx context.Context, page int) (*models.Loans, error) {
+		queryParam := map[string]string{
+			"currency": filter.Currency}
+		filter.setQuery(queryParam, page)
+		var loans models.Loans
+		if err := c.sendRequest(ctx, "GetLoans", "GET", "/loans", nil, &queryParam, &loans); err != nil {
+			return nil, err
+		}
+		return &loans, nil
+	})
+}
+
 func (c *Client) UpdateALoan(ctx context.Context, loanID int, fundReloaned bool) (*models.Loan, error) {
 	spath := fmt.Sprintf("/loans/%d", loanID)
-	bodyTemplate :=
-		`{
-			"loan": {
-				"fund_reloaned":%s
-			}
-		}`
-	body := fmt.Sprintf(bodyTemplate, strconv.FormatBool(fundReloaned))
-	res, err := c.sendRequest(ctx, "PUT", spath, strings.NewReader(body), nil)
+	var envelope loanEnvelope
+	envelope.Loan.FundReloaned = fundReloaned
+	body, err := jsonBody(&envelope)
 	if err != nil {
 		return nil, err
 	}
-
 	var loan models.Loan
-	if err := decodeBody(res, &loan); err != nil {
+	if err := c.sendRequest(ctx, "UpdateALoan", "PUT", spath, body, nil, &loan); err != nil {
 		return nil, err
 	}
 
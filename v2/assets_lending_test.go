@@ -11,9 +11,9 @@ import (
 
 func TestCreateALoanBid(t *testing.T) {
 	type Param struct {
-		quantity     string
+		quantity     models.Decimal
 		currency     string
-		rate         string
+		rate         models.Decimal
 		jsonResponse string
 	}
 	type Expect struct {
@@ -28,7 +28,7 @@ func TestCreateALoanBid(t *testing.T) {
 	}{
 		// test case 1
 		{
-			param:  Param{currency: "USD", quantity: "50", rate: "0.0002", jsonResponse: testutil.GetCreateLoanBidJsonResponse()},
+			param:  Param{currency: "USD", quantity: models.MustDecimal("50"), rate: models.MustDecimal("0.0002"), jsonResponse: testutil.GetCreateLoanBidJsonResponse()},
 			expect: Expect{path: "/loan_bids", method: "POST", body: testutil.GetExpectedCreateALoanBidRequestBody(), loanBid: testutil.GetExpectedCreateLoanBidModel()},
 		},
 		// test case 2
@@ -38,8 +38,7 @@ func TestCreateALoanBid(t *testing.T) {
 		ts := testutil.GenerateTestServer(t, c.expect.path, c.expect.method, c.expect.body, c.param.jsonResponse)
 		defer ts.Close()
 
-		client, _ := NewClient("apiTokenID", "secret", nil)
-		client.testServer = ts
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		loanBid, _ := client.CreateALoanBid(ctx, c.param.quantity, c.param.currency, c.param.rate)
@@ -77,8 +76,7 @@ func TestGetLoanBids(t *testing.T) {
 		ts := testutil.GenerateTestServer(t, c.expect.path, c.expect.method, c.expect.body, c.param.jsonResponse)
 		defer ts.Close()
 
-		client, _ := NewClient("apiTokenID", "secret", nil)
-		client.testServer = ts
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		loanBids, _ := client.GetLoanBids(ctx, c.param.currency)
@@ -116,8 +114,7 @@ func TestCloseLoanBid(t *testing.T) {
 		ts := testutil.GenerateTestServer(t, c.expect.path, c.expect.method, c.expect.body, c.param.jsonResponse)
 		defer ts.Close()
 
-		client, _ := NewClient("apiTokenID", "secret", nil)
-		client.testServer = ts
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		loanBid, _ := client.CloseLoanBid(ctx, c.param.loanBidID)
@@ -155,8 +152,7 @@ func TestGetLoans(t *testing.T) {
 		ts := testutil.GenerateTestServer(t, c.expect.path, c.expect.method, c.expect.body, c.param.jsonResponse)
 		defer ts.Close()
 
-		client, _ := NewClient("apiTokenID", "secret", nil)
-		client.testServer = ts
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		loans, _ := client.GetLoans(ctx, c.param.currency)
@@ -195,8 +191,7 @@ func TestUpdateALoan(t *testing.T) {
 		ts := testutil.GenerateTestServer(t, c.expect.path, c.expect.method, c.expect.body, c.param.jsonResponse)
 		defer ts.Close()
 
-		client, _ := NewClient("apiTokenID", "secret", nil)
-		client.testServer = ts
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		loan, _ := client.UpdateALoan(ctx, c.param.loanID, c.param.fundReloaned)
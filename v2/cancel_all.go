@@ -0,0 +1,108 @@
+package quoinex
+
+import (
+	"context"
+	"github.com/sho3imo/quoinex-go-client/v2/models"
+	"sync"
+)
+
+const defaultCancelConcurrency = 1
+
+// CancelAllOption configures CancelAllOrders.
+type CancelAllOption func(*cancelAllConfig)
+
+type cancelAllConfig struct {
+	concurrency int
+}
+
+// CancelConcurrency sets how many cancel requests are in flight at once.
+// The default is 1, since concurrent requests can reach the exchange out of
+// nonce order and be rejected; raise it only where nonces may arrive out of
+// order.
+func CancelConcurrency(n int) CancelAllOption {
+	return func(cfg *cancelAllConfig) {
+		if n > 0 {
+			cfg.concurrency = n
+		}
+	}
+}
+
+// CancelResult is the outcome of cancelling one order.
+type CancelResult struct {
+	// Order is the cancelled order, or as listed when it was not cancelled.
+	Order *models.Order
+	// NotLive reports that the order was filled or cancelled before the
+	// cancel request arrived. It is not an error.
+	NotLive bool
+	Err     error
+}
+
+// CancelReport lists a CancelResult per order, in listing order.
+type CancelReport struct {
+	Results []CancelResult
+}
+
+// Cancelled returns the number of orders this call cancelled.
+func (r *CancelReport) Cancelled() int {
+	n := 0
+	for _, res := range r.Results {
+		if res.Err == nil && !res.NotLive {
+			n++
+		}
+	}
+	return n
+}
+
+// Failed returns the results whose cancel request failed.
+func (r *CancelReport) Failed() []CancelResult {
+	var failed []CancelResult
+	for _, res := range r.Results {
+		if res.Err != nil {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
+// CancelAllOrders cancels every live order matching filter; filter.Status
+// is ignored. Every live order is listed first, since cancelling shifts
+// later pages, and then cancelled one at a time under the rate limiter.
+//
+// If listing fails part way the orders listed so far are still cancelled,
+// and the report is returned with the error. The requests are not recorded
+// in a ResponseMeta of ctx; the report describes them instead.
+func (c *Client) CancelAllOrders(ctx context.Context, filter OrderFilter, opts ...CancelAllOption) (*CancelReport, error) {
+	ctx = withoutResponseMeta(ctx)
+	cfg := cancelAllConfig{concurrency: defaultCancelConcurrency}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	filter.Status = models.OrderStatusLive
+	orders, listErr := c.Orders(ctx, filter).All()
+
+	report := &CancelReport{Results: make([]CancelResult, len(orders))}
+	sem := make(chan struct{}, cfg.concurrency)
+	var wg sync.WaitGroup
+	for i, o := range orders {
+		res := &report.Results[i]
+		res.Order = o
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			cancelled, err := c.CancelAnOrder(ctx, res.Order.ID)
+			switch {
+			case err == nil:
+				res.Order = cancelled
+			case IsOrderNotLive(err):
+				res.NotLive = true
+			default:
+				res.Err = err
+			}
+		}()
+	}
+	wg.Wait()
+
+	return report, listErr
+}
@@ -0,0 +1,86 @@
+package quoinex
+
+import (
+	"context"
+	"fmt"
+	"github.com/sho3imo/quoinex-go-client/v2/models"
+	"github.com/sho3imo/quoinex-go-client/v2/testutil"
+	"testing"
+	"time"
+)
+
+func TestCancelAllOrders(t *testing.T) {
+	fx := testutil.NewFakeExchange(t)
+	defer fx.Close()
+	fx.PageSize = 2
+	client, _ := NewClient("apiTokenID", "secret", WithBaseURL(fx.URL))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var ids []int
+	for i := 0; i < 5; i++ {
+		o, err := client.CreateOrder(ctx, &CreateOrderRequest{OrderType: models.OrderTypeLimit, ProductID: 1, Side: models.SideBuy,
+			Quantity: models.MustDecimal("0.01"), Price: decimalPtr(fmt.Sprintf("%d", 400+i))})
+		if err != nil {
+			t.Fatalf("Error. %+v", err)
+		}
+		ids = append(ids, o.ID)
+	}
+	other, err := client.CreateOrder(ctx, &CreateOrderRequest{OrderType: models.OrderTypeLimit, ProductID: 5, Side: models.SideBuy,
+		Quantity: models.MustDecimal("0.01"), Price: decimalPtr("40000")})
+	if err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	// one order fills under us and one cancel fails outright
+	fx.InjectFailure(testutil.Failure{Method: "PUT", Path: fmt.Sprintf("/orders/%d/cancel", ids[1]), StatusCode: 422, Body: `{"errors":{"order":["not_live"]}}`})
+	fx.InjectFailure(testutil.Failure{Method: "PUT", Path: fmt.Sprintf("/orders/%d/cancel", ids[3]), StatusCode: 400})
+
+	report, err := client.CancelAllOrders(ctx, OrderFilter{ProductID: 1})
+	if err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	if len(report.Results) != 5 || report.Cancelled() != 3 || len(report.Failed()) != 1 {
+		t.Fatalf("Wrong report. %+v", report)
+	}
+	for _, res := range report.Results {
+		switch res.Order.ID {
+		case ids[1]:
+			if !res.NotLive || res.Err != nil {
+				t.Errorf("Wrong result for the filled order. %+v", res)
+			}
+		case ids[3]:
+			if e, ok := asAPIError(res.Err); !ok || e.StatusCode != 400 {
+				t.Errorf("Wrong result for the failed cancel. %+v", res)
+			}
+		default:
+			if res.Err != nil || res.Order.Status != models.OrderStatusCancelled {
+				t.Errorf("Wrong result. %+v", res)
+			}
+		}
+	}
+	if got := fx.Order(other.ID); got.Status != models.OrderStatusLive {
+		t.Errorf("Wrong status of an order outside the filter. %+v", got)
+	}
+}
+
+func TestCancelAllOrdersListError(t *testing.T) {
+	s := testutil.NewServer(t)
+	defer s.Close()
+	s.Expect("GET", "/orders?page=1&product_id=1&status=live").Respond(200, `{"models":[{"id":1,"status":"live"}],"current_page":1,"total_pages":2}`)
+	s.Expect("GET", "/orders?page=2&product_id=1&status=live").Respond(401, `{"message":"Unauthorized"}`)
+	s.Expect("PUT", "/orders/1/cancel").Respond(200, `{"id":1,"status":"cancelled"}`)
+
+	client, _ := NewClient("apiTokenID", "secret", WithBaseURL(s.URL))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	report, err := client.CancelAllOrders(ctx, OrderFilter{ProductID: 1, Status: models.OrderStatusFilled})
+	if !IsUnauthorized(err) {
+		t.Errorf("Wrong error. %+v", err)
+	}
+	if report.Cancelled() != 1 {
+		t.Errorf("Wrong report. %+v", report)
+	}
+	if err := s.Verify(); err != nil {
+		t.Errorf("Error. %+v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package quoinex
+
+import (
+	"context"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sho3imo/quoinex-go-client/v2/models"
+	"github.com/sho3imo/quoinex-go-client/v2/testutil"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cassette")
+	if err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "orders.json")
+
+	// record against a live server
+	ts := testutil.GenerateTestServer(t, "/orders/2157474", "PUT", testutil.GetExpectedEditALiveOrderRequestBody(), testutil.GetEditALiveOrderJsonResponse())
+	recorder := testutil.NewRecordingTransport(path, nil)
+	client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL), WithHTTPClient(&http.Client{Transport: recorder}))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	recorded, err := client.EditALiveOrder(ctx, 2157474, models.MustDecimal("0.02"), models.MustDecimal("520.0"))
+	if err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	ts.Close()
+
+	b, _ := ioutil.ReadFile(path)
+	if strings.Contains(string(b), "eyJ") || !strings.Contains(string(b), "[SCRUBBED]") {
+		t.Errorf("Wrong cassette. auth header must be scrubbed: %s", b)
+	}
+
+	// replay with the server gone
+	replay := testutil.LoadReplayTransport(t, path)
+	client, _ = NewClient("apiTokenID", "secret", WithBaseURL(ts.URL), WithHTTPClient(&http.Client{Transport: replay}))
+	replayed, err := client.EditALiveOrder(ctx, 2157474, models.MustDecimal("0.02"), models.MustDecimal("520.0"))
+	if err != nil {
+		t.Errorf("Error. %+v", err)
+	}
+	if !cmp.Equal(replayed, recorded) {
+		t.Errorf("Wrong attribute. %+v", cmp.Diff(replayed, recorded))
+	}
+	if err := replay.Verify(); err != nil {
+		t.Errorf("Error. %+v", err)
+	}
+
+	// a request that was never recorded fails loudly
+	if _, err := client.GetAnOrder(ContextWithRetryPolicy(ctx, NoRetryPolicy), 1); err == nil || !strings.Contains(err.Error(), "no recorded interaction for GET /orders/1") {
+		t.Errorf("Wrong error. %+v", err)
+	}
+	if err := replay.Verify(); err == nil || !strings.Contains(err.Error(), "unmatched request GET /orders/1") {
+		t.Errorf("Wrong verify. %+v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package quoinex
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -9,10 +10,7 @@ import (
 	"github.com/sho3imo/quoinex-go-client/v2/models"
 	"io"
 	"io/ioutil"
-	"log"
 	"net/http"
-	"net/http/httptest"
-	"net/http/httputil"
 	"net/url"
 	"runtime"
 	"time"
@@ -28,13 +26,19 @@ type Client struct {
 	ApiTokenID string
 	ApiSecret  string
 	HTTPClient *http.Client
-	Logger     *log.Logger
-	testServer *httptest.Server
+	Logger     Logger
+	// RedactFields are JSON keys masked in debug body logs.
+	RedactFields []string
+	UserAgent    string
+	Clock        Clock
+	NonceSource  NonceSource
+	RateLimiter  *RateLimiter
+	RetryPolicy  *RetryPolicy
+	Middlewares  []Middleware
+	Metrics      Metrics
 }
 
-var LiquidAlreadyExistError = errors.New(`{"errors":{"client_order_id":["exists"]}}`)
-
-func NewClient(apiTokenID string, apiSecret string, logger *log.Logger) (*Client, error) {
+func NewClient(apiTokenID string, apiSecret string, opts ...ClientOption) (*Client, error) {
 	if len(apiTokenID) == 0 {
 		return nil, fmt.Errorf("apiTokenID is not set")
 	}
@@ -48,25 +52,38 @@ func NewClient(apiTokenID string, apiSecret string, logger *log.Logger) (*Client
 		return nil, err
 	}
 
-	var discardLogger = log.New(ioutil.Discard, "", log.LstdFlags)
-	if logger == nil {
-		logger = discardLogger
+	retryPolicy := DefaultRetryPolicy
+	client := &Client{
+		URL:          url,
+		ApiTokenID:   apiTokenID,
+		ApiSecret:    apiSecret,
+		HTTPClient:   &http.Client{Timeout: time.Duration(10) * time.Second},
+		Logger:       nopLogger{},
+		RedactFields: append([]string(nil), defaultRedactFields...),
+		UserAgent:    fmt.Sprintf("GoClient/%s (%s)", version, runtime.Version()),
+		Clock:        systemClock{},
+		RateLimiter:  NewRateLimiter(defaultRateLimitRequests, defaultRateLimitPeriod),
+		RetryPolicy:  &retryPolicy,
+		Metrics:      nopMetrics{},
 	}
-
-	client := &http.Client{Timeout: time.Duration(10) * time.Second}
-	return &Client{URL: url, ApiTokenID: apiTokenID, ApiSecret: apiSecret, HTTPClient: client, Logger: logger}, nil
-
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if err := opt(client); err != nil {
+			return nil, err
+		}
+	}
+	if client.NonceSource == nil {
+		client.NonceSource = NewMonotonicNonceSource(client.Clock)
+	}
+	return client, nil
 }
 
 func (c *Client) GetInterestRates(ctx context.Context, currency string) (*models.InterestRates, error) {
 	spath := fmt.Sprintf("/ir_ladders/%s", currency)
-	res, err := c.sendRequest(ctx, "GET", spath, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
 	var interestRates models.InterestRates
-	if err := decodeBody(res, &interestRates); err != nil {
+	if err := c.sendRequest(ctx, "GetInterestRates", "GET", spath, nil, nil, &interestRates); err != nil {
 		return nil, err
 	}
 
@@ -85,13 +102,8 @@ func (c *Client) GetOrderBook(ctx context.Context, productID int, full bool) (*m
 		queryParam = nil
 	}
 
-	res, err := c.sendRequest(ctx, "GET", spath, nil, queryParam)
-	if err != nil {
-		return nil, err
-	}
-
 	var priceLevels models.PriceLevels
-	if err := decodeBody(res, &priceLevels); err != nil {
+	if err := c.sendRequest(ctx, "GetOrderBook", "GET", spath, nil, queryParam, &priceLevels); err != nil {
 		return nil, err
 	}
 
@@ -100,13 +112,8 @@ func (c *Client) GetOrderBook(ctx context.Context, productID int, full bool) (*m
 
 func (c *Client) GetProducts(ctx context.Context) ([]*models.Product, error) {
 	spath := fmt.Sprintf("/products")
-	res, err := c.sendRequest(ctx, "GET", spath, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
 	var products []*models.Product
-	if err := decodeBody(res, &products); err != nil {
+	if err := c.sendRequest(ctx, "GetProducts", "GET", spath, nil, nil, &products); err != nil {
 		return nil, err
 	}
 
@@ -115,31 +122,25 @@ func (c *Client) GetProducts(ctx context.Context) ([]*models.Product, error) {
 
 func (c *Client) GetProduct(ctx context.Context, productID int) (*models.Product, error) {
 	spath := fmt.Sprintf("/products/%d", productID)
-	res, err := c.sendRequest(ctx, "GET", spath, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
 	var product models.Product
-	if err := decodeBody(res, &product); err != nil {
+	if err := c.sendRequest(ctx, "GetProduct", "GET", spath, nil, nil, &product); err != nil {
 		return nil, err
 	}
 
 	return &product, nil
 }
 
-func (c *Client) newRequest(ctx context.Context, method, spath string, body io.Reader, queryParam *map[string]string) (*http.Request, error) {
-
-	// swith client url for unit test
-	if c.testServer != nil {
-		url, _ := url.ParseRequestURI(c.testServer.URL)
-		*c.URL = *url
-	}
-
+func (c *Client) newRequest(ctx context.Context, method, spath string, body io.Reader, queryParam *map[string]string, nonce int64) (*http.Request, error) {
 	u := *c.URL
 	// can't use path.Join in case of end with slash ex: http://quoinex/orders/
 	// u.Path = path.Join(c.URL.Path, spath)
-	u.Path = c.URL.Path + spath
+	// spath is escaped already and is signed as sent
+	unescaped, err := url.PathUnescape(spath)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = c.URL.Path + unescaped
+	u.RawPath = c.URL.EscapedPath() + spath
 
 	// build QueryParameter
 	if queryParam != nil {
@@ -152,13 +153,12 @@ func (c *Client) newRequest(ctx context.Context, method, spath string, body io.R
 		u.RawQuery = q.Encode()
 	}
 
-	userAgent := fmt.Sprintf("GoClient/%s (%s)", version, runtime.Version())
 	if u.RawQuery != "" {
 		spath = fmt.Sprintf("%s?%s", spath, u.RawQuery)
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"path":     spath,
-		"nonce":    time.Now().Unix(),
+		"nonce":    nonce,
 		"token_id": c.ApiTokenID,
 	})
 
@@ -175,54 +175,149 @@ func (c *Client) newRequest(ctx context.Context, method, spath string, body io.R
 	req = req.WithContext(ctx)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("X-Quoine-API-Version", "2")
-	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("User-Agent", c.UserAgent)
 	req.Header.Set("X-Quoine-Auth", tokenString)
 
 	return req, nil
 }
 
-func (c *Client) sendRequest(ctx context.Context, method, spath string, body io.Reader, queryParam *map[string]string) (*http.Response, error) {
-	req, err := c.newRequest(ctx, method, spath, body, queryParam)
-	c.Logger.Printf("Request:  %s \n", httpRequestLog(req))
+func (c *Client) sendRequest(ctx context.Context, op, method, spath string, body io.Reader, queryParam *map[string]string, out interface{}) error {
+	// buffer the body so that it can be replayed on retry
+	var payload []byte
+	if body != nil {
+		b, err := ioutil.ReadAll(body)
+		if err != nil {
+			return err
+		}
+		payload = b
+	}
+
+	meta := responseMetaFrom(ctx)
+	if meta != nil {
+		start := c.Clock.Now()
+		defer func() {
+			meta.Duration = c.Clock.Now().Sub(start)
+			if c.RateLimiter != nil {
+				meta.RateLimit = c.RateLimiter.Budget()
+			}
+		}()
+	}
+
+	policy := c.retryPolicy(ctx)
+	state, idempotent := ctx.Value(retryStateKey{}).(*retryState)
+	retryable := method == "GET" || idempotent
+
+	for attempt := 1; ; attempt++ {
+		if state != nil {
+			state.attempts = attempt
+		}
+		err := c.sendOnce(ctx, &Request{Operation: op, Method: method, Path: spath, Attempt: attempt, Body: payload, Out: out}, queryParam)
+		if !retryable || attempt >= policy.MaxAttempts || !shouldRetry(ctx, err) {
+			return err
+		}
+
+		delay := policy.backoff(attempt)
+		if ra := retryAfter(err, c.Clock.Now()); ra > delay {
+			delay = ra
+		}
+		// context deadlines are on the wall clock, not c.Clock
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return err
+		}
+		c.Logger.Log(ctx, LogLevelWarn, "retrying request",
+			LogField{"operation", op}, LogField{"method", method}, LogField{"path", spath},
+			LogField{"attempt", attempt}, LogField{"delay", delay}, LogField{"error", err})
+		if sleepErr := sleepContext(ctx, delay); sleepErr != nil {
+			return err
+		}
+	}
+}
+
+func (c *Client) sendOnce(ctx context.Context, call *Request, queryParam *map[string]string) error {
+	if c.RateLimiter != nil {
+		start := time.Now()
+		err := c.RateLimiter.Wait(ctx)
+		c.Metrics.ObserveRateLimitWait(ctx, call.Operation, time.Since(start))
+		if err != nil {
+			return err
+		}
+	}
+
+	var body io.Reader
+	if call.Body != nil {
+		body = bytes.NewReader(call.Body)
+	}
+	call.Nonce = c.NonceSource.Nonce()
+	req, err := c.newRequest(ctx, call.Method, call.Path, body, queryParam, call.Nonce)
+	if err != nil {
+		c.Logger.Log(ctx, LogLevelError, "failed to build request",
+			LogField{"operation", call.Operation}, LogField{"method", call.Method}, LogField{"path", call.Path}, LogField{"error", err})
+		return err
+	}
+	call.HTTPRequest = req
+
+	start := time.Now()
+	res, err := chain(c.roundTrip, c.Middlewares)(ctx, call)
+	latency := time.Since(start)
+	if meta := responseMetaFrom(ctx); meta != nil {
+		meta.recordAttempt(call, res, err, latency, c.Clock.Now())
+	}
+	metric := RequestMetric{Operation: call.Operation, Method: call.Method, Attempt: call.Attempt, ErrorClass: errorClass(err), Latency: latency}
+	var apiErr *APIError
+	if res != nil && res.HTTPResponse != nil {
+		metric.StatusCode = res.HTTPResponse.StatusCode
+	} else if errors.As(err, &apiErr) {
+		metric.StatusCode = apiErr.StatusCode
+	}
+	c.Metrics.ObserveRequest(ctx, metric)
+	return err
+}
+
+// roundTrip is the innermost RoundTripFunc: it sends the signed request and
+// decodes a 200 response into call.Out.
+func (c *Client) roundTrip(ctx context.Context, call *Request) (*Response, error) {
+	if c.Logger.Enabled(LogLevelDebug) {
+		c.Logger.Log(ctx, LogLevelDebug, "request",
+			LogField{"operation", call.Operation}, LogField{"method", call.Method}, LogField{"url", call.HTTPRequest.URL.String()},
+			LogField{"header", redactHeader(call.HTTPRequest.Header)}, LogField{"body", redactBody(call.Body, c.RedactFields)})
+	}
+
+	start := time.Now()
+	res, err := c.HTTPClient.Do(call.HTTPRequest)
+	latency := time.Since(start)
 	if err != nil {
-		c.Logger.Printf("err: %#v \n", err)
+		c.Logger.Log(ctx, LogLevelError, "request failed",
+			LogField{"operation", call.Operation}, LogField{"method", call.Method}, LogField{"path", call.Path},
+			LogField{"latency", latency}, LogField{"error", err})
 		return nil, err
 	}
+	defer res.Body.Close()
+
+	level := LogLevelInfo
+	if res.StatusCode != 200 {
+		level = LogLevelWarn
+	}
+	c.Logger.Log(ctx, level, "response",
+		LogField{"operation", call.Operation}, LogField{"method", call.Method}, LogField{"path", call.Path},
+		LogField{"status", res.StatusCode}, LogField{"latency", latency}, LogField{"request_id", res.Header.Get("X-Request-Id")})
 
-	res, err := c.HTTPClient.Do(req)
-	c.Logger.Printf("Response: %s \n", httpResponseLog(res))
+	b, err := ioutil.ReadAll(res.Body)
 	if err != nil {
-		c.Logger.Printf("err: %#v \n", err)
 		return nil, err
 	}
+	if c.Logger.Enabled(LogLevelDebug) {
+		c.Logger.Log(ctx, LogLevelDebug, "response body",
+			LogField{"operation", call.Operation}, LogField{"method", call.Method}, LogField{"path", call.Path},
+			LogField{"header", redactHeader(res.Header)}, LogField{"body", redactBody(b, c.RedactFields)})
+	}
 
 	if res.StatusCode != 200 {
-		defer res.Body.Close()
-		bytes, err := ioutil.ReadAll(res.Body)
-		if err != nil {
+		return nil, newAPIError(call.Method, call.Path, res, b)
+	}
+	if call.Out != nil {
+		if err := json.Unmarshal(b, call.Out); err != nil {
 			return nil, err
 		}
-		b := string(bytes)
-		if b == (`{"errors":{"client_order_id":["exists"]}}`) {
-			return nil, LiquidAlreadyExistError
-		} else {
-			return nil, errors.New(b)
-		}
 	}
-	return res, nil
-}
-
-func decodeBody(resp *http.Response, out interface{}) error {
-	defer resp.Body.Close()
-	decoder := json.NewDecoder(resp.Body)
-	return decoder.Decode(out)
-}
-
-func httpResponseLog(resp *http.Response) string {
-	b, _ := httputil.DumpResponse(resp, true)
-	return string(b)
-}
-func httpRequestLog(req *http.Request) string {
-	b, _ := httputil.DumpRequest(req, true)
-	return string(b)
+	return &Response{HTTPResponse: res, Body: b, Result: call.Out}, nil
 }
@@ -9,7 +9,9 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
 	"reflect"
+	"runtime"
 	"testing"
 	"time"
 )
@@ -18,60 +20,97 @@ func TestNewClient(t *testing.T) {
 	type Param struct {
 		apiToken  string
 		apiSecret string
-		logger    *log.Logger
+		opts      []ClientOption
 	}
 	type Expect struct {
 		client *Client
 		err    error
 	}
+	httpClient := &http.Client{Timeout: time.Duration(3) * time.Second}
+	logger := NewStdLogger(log.New(ioutil.Discard, "test", log.LstdFlags), LogLevelInfo)
 	cases := []struct {
 		param  Param
 		expect Expect
 	}{
 		// test case 1
 		{
-			param:  Param{apiToken: "", apiSecret: "secret", logger: nil},
+			param:  Param{apiToken: "", apiSecret: "secret", opts: nil},
 			expect: Expect{client: nil, err: fmt.Errorf("apiTokenID is not set")},
 		},
 		// test case 2
 		{
-			param:  Param{apiToken: "apiToken", apiSecret: "", logger: nil},
+			param:  Param{apiToken: "apiToken", apiSecret: "", opts: nil},
 			expect: Expect{client: nil, err: fmt.Errorf("apiSecret is not set")},
 		},
 		// test case 3
 		{
-			param: Param{apiToken: "apiToken", apiSecret: "apiSecret", logger: nil},
+			param: Param{apiToken: "apiToken", apiSecret: "apiSecret", opts: nil},
 			expect: Expect{client: &Client{
+				URL:        &url.URL{Scheme: "https", Host: "api.liquid.com"},
 				ApiTokenID: "apiToken",
 				ApiSecret:  "apiSecret",
 				HTTPClient: &http.Client{Timeout: time.Duration(10) * time.Second},
-				Logger:     log.New(ioutil.Discard, "", log.LstdFlags),
+				Logger:     nopLogger{},
+				UserAgent:  fmt.Sprintf("GoClient/%s (%s)", version, runtime.Version()),
 			}, err: nil},
 		},
+		// test case 4
+		{
+			param: Param{apiToken: "apiToken", apiSecret: "apiSecret", opts: []ClientOption{
+				WithBaseURL("http://127.0.0.1:8080/v2"),
+				WithHTTPClient(httpClient),
+				WithTimeout(time.Duration(5) * time.Second),
+				WithUserAgent("bot/1.0"),
+				WithLogger(logger),
+			}},
+			expect: Expect{client: &Client{
+				URL:        &url.URL{Scheme: "http", Host: "127.0.0.1:8080", Path: "/v2"},
+				ApiTokenID: "apiToken",
+				ApiSecret:  "apiSecret",
+				HTTPClient: &http.Client{Timeout: time.Duration(5) * time.Second},
+				Logger:     logger,
+				UserAgent:  "bot/1.0",
+			}, err: nil},
+		},
+		// test case 5
+		{
+			param:  Param{apiToken: "apiToken", apiSecret: "apiSecret", opts: []ClientOption{WithBaseURL("not a url")}},
+			expect: Expect{client: nil, err: fmt.Errorf(`parse "not a url": invalid URI for request`)},
+		},
 	}
 	for _, c := range cases {
-		client, e := NewClient(c.param.apiToken, c.param.apiSecret, c.param.logger)
+		client, e := NewClient(c.param.apiToken, c.param.apiSecret, c.param.opts...)
 		if client == nil && e.Error() != c.expect.err.Error() {
-			t.Errorf("Worng err. test set is %+v", c)
+			t.Errorf("Wrong err. test set is %+v, actual: %+v", c, e)
 		}
 		if client == nil {
 			t.Logf("client is nil. skip this case.: test set: %+v", c)
 			continue
 		}
+		if client.URL.String() != c.expect.client.URL.String() {
+			t.Errorf("Wrong URL. test set: %+v", c)
+		}
 		if client.ApiTokenID != c.expect.client.ApiTokenID {
-			t.Errorf("Worng apiToken. test set: %+v", c)
+			t.Errorf("Wrong apiToken. test set: %+v", c)
 		}
 		if client.ApiSecret != c.expect.client.ApiSecret {
-			t.Errorf("Worng ApiSecret. test set: %+v", c)
+			t.Errorf("Wrong ApiSecret. test set: %+v", c)
 		}
-		if reflect.TypeOf(client.HTTPClient) != reflect.TypeOf(c.expect.client.HTTPClient) {
-			t.Errorf("Worng HTTPClient. test set: %+v", c)
+		if client.HTTPClient.Timeout != c.expect.client.HTTPClient.Timeout {
+			t.Errorf("Wrong HTTPClient. test set: %+v", c)
 		}
 		if reflect.TypeOf(client.Logger) != reflect.TypeOf(c.expect.client.Logger) {
-			t.Errorf("Worng Logger. test set: %+v", c)
+			t.Errorf("Wrong Logger. test set: %+v", c)
 		}
+		if client.UserAgent != c.expect.client.UserAgent {
+			t.Errorf("Wrong UserAgent. test set: %+v", c)
+		}
+	}
+	if httpClient.Timeout != time.Duration(3)*time.Second {
+		t.Errorf("WithTimeout must not modify the caller's http.Client")
 	}
 }
+
 func TestNewRequest(t *testing.T) {
 	type Param struct {
 		method     string
@@ -99,10 +138,10 @@ func TestNewRequest(t *testing.T) {
 	}
 
 	for _, c := range cases {
-		client, _ := NewClient("apiTokenID", "secret", nil)
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL("https://api.quoine.com"))
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
-		req, _ := client.newRequest(ctx, c.param.method, c.param.spath, nil, c.param.queryParam)
+		req, _ := client.newRequest(ctx, c.param.method, c.param.spath, nil, c.param.queryParam, client.NonceSource.Nonce())
 		if req.Method != c.expect.method {
 			t.Errorf("Worng method. case: %+v", c)
 		}
@@ -141,8 +180,7 @@ func TestGetProducts(t *testing.T) {
 		ts := testutil.GenerateTestServer(t, c.expect.path, c.expect.method, c.expect.body, c.param.jsonResponse)
 		defer ts.Close()
 
-		client, _ := NewClient("apiTokenID", "secret", nil)
-		client.testServer = ts
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		products, err := client.GetProducts(ctx)
@@ -184,8 +222,7 @@ func TestGetProduct(t *testing.T) {
 		ts := testutil.GenerateTestServer(t, c.expect.path, c.expect.method, c.expect.body, c.param.jsonResponse)
 		defer ts.Close()
 
-		client, _ := NewClient("apiTokenID", "secret", nil)
-		client.testServer = ts
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		product, err := client.GetProduct(ctx, c.param.productID)
@@ -226,8 +263,7 @@ func TestGetOrderBook(t *testing.T) {
 		ts := testutil.GenerateTestServer(t, c.expect.path, c.expect.method, c.expect.body, c.param.jsonResponse)
 		defer ts.Close()
 
-		client, _ := NewClient("apiTokenID", "secret", nil)
-		client.testServer = ts
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		priceLevels, err := client.GetOrderBook(ctx, c.param.productID, true)
@@ -266,8 +302,7 @@ func TestGetInterestRates(t *testing.T) {
 		ts := testutil.GenerateTestServer(t, c.expect.path, c.expect.method, c.expect.body, c.param.jsonResponse)
 		defer ts.Close()
 
-		client, _ := NewClient("apiTokenID", "secret", nil)
-		client.testServer = ts
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		r, err := client.GetInterestRates(ctx, c.param.currency)
@@ -0,0 +1,134 @@
+package quoinex
+
+import (
+	"context"
+	"fmt"
+	"github.com/sho3imo/quoinex-go-client/v2/models"
+)
+
+// CreateCryptoWithdrawal requests a withdrawal from the crypto account of
+// req.Currency. An amount below the account's MinimumWithdraw fails with a
+// *MinimumWithdrawError before anything is sent.
+func (c *Client) CreateCryptoWithdrawal(ctx context.Context, req *CryptoWithdrawalRequest) (*models.CryptoWithdrawal, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request is nil")
+	}
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+	if err := c.checkMinimumWithdraw(withoutResponseMeta(ctx), req.Currency, req.Amount); err != nil {
+		return nil, err
+	}
+	spath := fmt.Sprintf("/crypto_withdrawals")
+	body, err := jsonBody(&cryptoWithdrawalEnvelope{AuthCode: req.AuthCode, CryptoWithdrawal: req})
+	if err != nil {
+		return nil, err
+	}
+	var withdrawal models.CryptoWithdrawal
+	if err := c.sendRequest(ctx, "CreateCryptoWithdrawal", "POST", spath, body, nil, &withdrawal); err != nil {
+		return nil, err
+	}
+
+	return &withdrawal, nil
+}
+
+func (c *Client) checkMinimumWithdraw(ctx context.Context, currency string, amount models.Decimal) error {
+	accounts, err := c.GetCryptoAccounts(ctx)
+	if err != nil {
+		return err
+	}
+	for _, a := range accounts {
+		if a.Currency != currency {
+			continue
+		}
+		if amount.LessThan(a.MinimumWithdraw) {
+			return &MinimumWithdrawError{Currency: currency, Amount: amount, Minimum: a.MinimumWithdraw}
+		}
+		return nil
+	}
+	return fmt.Errorf("no crypto account for %s", currency)
+}
+
+// GetCryptoWithdrawals returns one page of the crypto withdrawals matching
+// filter: the page of filter.Page, or the first. Use CryptoWithdrawals to
+// walk every page.
+func (c *Client) GetCryptoWithdrawals(ctx context.Context, filter CryptoWithdrawalFilter) (*models.CryptoWithdrawals, error) {
+	if err := filter.State.Validate(); err != nil {
+		return nil, err
+	}
+	spath := fmt.Sprintf("/crypto_withdrawals")
+	queryParam := filter.query()
+	filter.setPageQuery(queryParam)
+	var withdrawals models.CryptoWithdrawals
+	if err := c.sendRequest(ctx, "GetCryptoWithdrawals", "GET", spath, nil, &queryParam, &withdrawals); err != nil {
+		return nil, err
+	}
+
+	return &withdrawals, nil
+}
+
+// CryptoWithdrawalFilter selects the withdrawals of GetCryptoWithdrawals
+// and CryptoWithdrawals. Zero fields are not sent.
+type CryptoWithdrawalFilter struct {
+	Currency string
+	State    models.WithdrawalState
+	ListOptions
+}
+
+func (f CryptoWithdrawalFilter) query() map[string]string {
+	return map[string]string{
+		"currency": f.Currency,
+		"state":    string(f.State)}
+}
+
+// CryptoWithdrawalIterator walks every crypto withdrawal matching a filter,
+// one page at a time.
+type CryptoWithdrawalIterator = Iterator[*models.CryptoWithdrawal]
+
+// NewCryptoWithdrawalIterator returns an iterator that loads each page with fetch.
+func NewCryptoWithdrawalIterator(ctx context.Context, opts ListOptions, fetch func(ctx context.Context, page int) (*models.CryptoWithdrawals, error)) *CryptoWithdrawalIterator {
+	return newPageIterator(ctx, opts, fetch, func(p *models.CryptoWithdrawals) ([]*models.CryptoWithdrawal, int) { return p.Models, p.TotalPages })
+}
+
+// CryptoWithdrawals returns an iterator over every crypto withdrawal
+// matching filter.
+func (c *Client) CryptoWithdrawals(ctx context.Context, filter CryptoWithdrawalFilter) *CryptoWithdrawalIterator {
+	it := NewCryptoWithdrawalIterator(ctx, filter.ListOptions, func(ctx context.Context, page int) (*models.CryptoWithdrawals, error) {
+		queryParam := filter.query()
+		filter.setQuery(queryParam, page)
+		var withdrawals models.CryptoWithdrawals
+		if err := c.sendRequest(ctx, "GetCryptoWithdrawals", "GET", "/crypto_withdrawals", nil, &queryParam, &withdrawals); err != nil {
+			return nil, err
+		}
+		return &withdrawals, nil
+	})
+	if err := filter.State.Validate(); err != nil {
+		it.stop(err)
+	}
+	return it
+}
+
+// CancelCryptoWithdrawal cancels a pending withdrawal.
+func (c *Client) CancelCryptoWithdrawal(ctx context.Context, withdrawalID int) (*models.CryptoWithdrawal, error) {
+	spath := fmt.Sprintf("/crypto_withdrawals/%d/cancel", withdrawalID)
+	var withdrawal models.CryptoWithdrawal
+	if err := c.sendRequest(ctx, "CancelCryptoWithdrawal", "PUT", spath, nil, nil, &withdrawal); err != nil {
+		return nil, err
+	}
+
+	return &withdrawal, nil
+}
+
+// GetWithdrawalAddresses lists the registered withdrawal addresses, of
+// every currency when currency is empty.
+func (c *Client) GetWithdrawalAddresses(ctx context.Context, currency string) ([]*models.WithdrawalAddress, error) {
+	spath := fmt.Sprintf("/withdrawal_addresses")
+	queryParam := &map[string]string{
+		"currency": currency}
+	var addresses []*models.WithdrawalAddress
+	if err := c.sendRequest(ctx, "GetWithdrawalAddresses", "GET", spath, nil, queryParam, &addresses); err != nil {
+		return nil, err
+	}
+
+	return addresses, nil
+}
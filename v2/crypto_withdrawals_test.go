@@ -0,0 +1,135 @@
+package quoinex
+
+import (
+	"context"
+	"errors"
+	"github.com/sho3imo/quoinex-go-client/v2/models"
+	"github.com/sho3imo/quoinex-go-client/v2/testutil"
+	"testing"
+	"time"
+)
+
+func TestCreateCryptoWithdrawal(t *testing.T) {
+	accounts := `[{"id":2,"currency":"BTC","balance":"1.0","minimum_withdraw":"0.001"}]`
+	type Param struct {
+		req   *CryptoWithdrawalRequest
+		setup func(s *testutil.Server)
+	}
+	type Expect struct {
+		id           int
+		err          bool
+		belowMinimum bool
+	}
+	cases := []struct {
+		param  Param
+		expect Expect
+	}{
+		// test case 1
+		{param: Param{
+			req: &CryptoWithdrawalRequest{Currency: "BTC", Amount: models.MustDecimal("0.5"), Address: "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", AuthCode: "123456"},
+			setup: func(s *testutil.Server) {
+				s.Expect("GET", "/crypto_accounts").Respond(200, accounts)
+				s.Expect("POST", "/crypto_withdrawals").
+					WithJSONBody(`{"auth_code":"123456","crypto_withdrawal":{"currency":"BTC","amount":"0.5","address":"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2"}}`).
+					Respond(200, `{"id":11,"currency":"BTC","amount":"0.5","state":"pending"}`)
+			},
+		}, expect: Expect{id: 11}},
+		// test case 2: below the minimum, nothing is sent
+		{param: Param{
+			req: &CryptoWithdrawalRequest{Currency: "BTC", Amount: models.MustDecimal("0.0005"), Address: "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2"},
+			setup: func(s *testutil.Server) {
+				s.Expect("GET", "/crypto_accounts").Respond(200, accounts)
+			},
+		}, expect: Expect{err: true, belowMinimum: true}},
+		// test case 3
+		{param: Param{
+			req: &CryptoWithdrawalRequest{Currency: "ETH", Amount: models.MustDecimal("1"), Address: "0xabc"},
+			setup: func(s *testutil.Server) {
+				s.Expect("GET", "/crypto_accounts").Respond(200, accounts)
+			},
+		}, expect: Expect{err: true}},
+		// test case 4
+		{param: Param{
+			req:   &CryptoWithdrawalRequest{Currency: "BTC", Amount: models.MustDecimal("0.5")},
+			setup: func(s *testutil.Server) {},
+		}, expect: Expect{err: true}},
+		// test case 5
+		{param: Param{
+			req:   &CryptoWithdrawalRequest{Currency: "BTC", Address: "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2"},
+			setup: func(s *testutil.Server) {},
+		}, expect: Expect{err: true}},
+	}
+	for i, c := range cases {
+		s := testutil.NewServer(t).InOrder()
+		c.param.setup(s)
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(s.URL))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		withdrawal, err := client.CreateCryptoWithdrawal(ctx, c.param.req)
+		cancel()
+		if (err != nil) != c.expect.err {
+			t.Errorf("Wrong error in case %d. %+v", i+1, err)
+		}
+		var minErr *MinimumWithdrawError
+		if errors.As(err, &minErr) != c.expect.belowMinimum {
+			t.Errorf("Wrong MinimumWithdrawError in case %d. %+v", i+1, err)
+		}
+		if err == nil && withdrawal.ID != c.expect.id {
+			t.Errorf("Wrong withdrawal in case %d. %+v", i+1, withdrawal)
+		}
+		if err := s.Verify(); err != nil {
+			t.Errorf("Error in case %d. %+v", i+1, err)
+		}
+		s.Close()
+	}
+}
+
+func TestCryptoWithdrawalLifecycle(t *testing.T) {
+	fx := testutil.NewFakeExchange(t)
+	defer fx.Close()
+	fx.PageSize = 2
+	fx.AddWithdrawalAddress(&models.WithdrawalAddress{Address: "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", Currency: "BTC", Label: "cold"})
+	fx.AddWithdrawalAddress(&models.WithdrawalAddress{Address: "0xabc", Currency: "ETH"})
+	client, _ := NewClient("apiTokenID", "secret", WithBaseURL(fx.URL))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	addresses, err := client.GetWithdrawalAddresses(ctx, "BTC")
+	if err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	if len(addresses) != 1 || addresses[0].Label != "cold" {
+		t.Fatalf("Wrong addresses. %+v", addresses)
+	}
+	var ids []int
+	for i := 0; i < 3; i++ {
+		w, err := client.CreateCryptoWithdrawal(ctx, &CryptoWithdrawalRequest{Currency: "BTC", Amount: models.MustDecimal("0.1"), Address: addresses[0].Address})
+		if err != nil {
+			t.Fatalf("Error. %+v", err)
+		}
+		ids = append(ids, w.ID)
+	}
+	if _, err := client.CancelCryptoWithdrawal(ctx, ids[0]); err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	if _, err := client.CancelCryptoWithdrawal(ctx, ids[0]); err == nil {
+		t.Errorf("Wrong error cancelling a cancelled withdrawal")
+	}
+
+	pending, err := client.CryptoWithdrawals(ctx, CryptoWithdrawalFilter{Currency: "BTC", State: models.WithdrawalStatePending}).All()
+	if err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	if len(pending) != 2 || pending[0].ID != ids[2] || pending[1].ID != ids[1] {
+		t.Errorf("Wrong pending withdrawals. %+v", pending)
+	}
+	page, err := client.GetCryptoWithdrawals(ctx, CryptoWithdrawalFilter{Currency: "BTC", ListOptions: ListOptions{Page: 2}})
+	if err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	if page.TotalPages != 2 || len(page.Models) != 1 || page.Models[0].State != models.WithdrawalStateCancelled {
+		t.Errorf("Wrong page. %+v", page)
+	}
+	if _, err := client.GetCryptoWithdrawals(ctx, CryptoWithdrawalFilter{State: "unknown"}); err == nil {
+		t.Errorf("Wrong error for an unknown state")
+	}
+}
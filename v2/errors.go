@@ -0,0 +1,141 @@
+package quoinex
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/sho3imo/quoinex-go-client/v2/models"
+	"net/http"
+	"strings"
+)
+
+var LiquidAlreadyExistError = errors.New(`{"errors":{"client_order_id":["exists"]}}`)
+
+// MinimumWithdrawError is returned by CreateCryptoWithdrawal, without
+// sending the request, when the amount is below the account's minimum.
+type MinimumWithdrawError struct {
+	Currency string
+	Amount   models.Decimal
+	Minimum  models.Decimal
+}
+
+func (e *MinimumWithdrawError) Error() string {
+	return fmt.Sprintf("withdrawal of %s %s is below the minimum of %s", e.Amount, e.Currency, e.Minimum)
+}
+
+// APIError is returned for every non-200 response from the exchange.
+type APIError struct {
+	StatusCode int
+	Method     string
+	Path       string
+	Header     http.Header
+	Body       []byte
+	// Errors is the decoded {"errors":{field:[codes]}} payload, if any.
+	Errors map[string][]string
+	// Message is the decoded {"message":"..."} payload, if any.
+	Message string
+}
+
+func newAPIError(method, spath string, res *http.Response, body []byte) *APIError {
+	e := &APIError{StatusCode: res.StatusCode, Method: method, Path: spath, Header: res.Header, Body: body}
+
+	var payload struct {
+		Errors  map[string][]string `json:"errors"`
+		Message string              `json:"message"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil {
+		e.Errors = payload.Errors
+		e.Message = payload.Message
+	}
+	return e
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s %s: %d %s: %s", e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode), string(e.Body))
+}
+
+// Is lets errors.Is(err, LiquidAlreadyExistError) match a duplicate client_order_id response.
+func (e *APIError) Is(target error) bool {
+	return target == LiquidAlreadyExistError && e.IsDuplicateClientOrderID()
+}
+
+// HasCode reports whether field carries the given error code.
+func (e *APIError) HasCode(field, code string) bool {
+	for _, c := range e.Errors[field] {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *APIError) IsNotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+func (e *APIError) IsUnauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+func (e *APIError) IsRateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests
+}
+
+func (e *APIError) IsInsufficientBalance() bool {
+	for _, codes := range e.Errors {
+		for _, c := range codes {
+			if strings.HasPrefix(c, "not_enough") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (e *APIError) IsDuplicateClientOrderID() bool {
+	return e.HasCode("client_order_id", "exists")
+}
+
+// IsOrderNotLive reports a cancel or edit of an order that is no longer
+// live, typically because it filled first.
+func (e *APIError) IsOrderNotLive() bool {
+	return e.HasCode("order", "not_live")
+}
+
+func asAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+func IsNotFound(err error) bool {
+	e, ok := asAPIError(err)
+	return ok && e.IsNotFound()
+}
+
+func IsUnauthorized(err error) bool {
+	e, ok := asAPIError(err)
+	return ok && e.IsUnauthorized()
+}
+
+func IsRateLimited(err error) bool {
+	e, ok := asAPIError(err)
+	return ok && e.IsRateLimited()
+}
+
+func IsInsufficientBalance(err error) bool {
+	e, ok := asAPIError(err)
+	return ok && e.IsInsufficientBalance()
+}
+
+func IsDuplicateClientOrderID(err error) bool {
+	e, ok := asAPIError(err)
+	return ok && e.IsDuplicateClientOrderID()
+}
+
+func IsOrderNotLive(err error) bool {
+	e, ok := asAPIError(err)
+	return ok && e.IsOrderNotLive()
+}
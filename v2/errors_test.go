@@ -0,0 +1,118 @@
+package quoinex
+
+import (
+	"context"
+	"errors"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sho3imo/quoinex-go-client/v2/testutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestAPIError(t *testing.T) {
+	type Param struct {
+		statusCode   int
+		jsonResponse string
+	}
+	type Expect struct {
+		errors                map[string][]string
+		message               string
+		notFound              bool
+		unauthorized          bool
+		rateLimited           bool
+		insufficientBalance   bool
+		duplicateClientOrder  bool
+		isLiquidAlreadyExists bool
+		orderNotLive          bool
+	}
+	cases := []struct {
+		param  Param
+		expect Expect
+	}{
+		// test case 1
+		{
+			param: Param{statusCode: http.StatusUnprocessableEntity, jsonResponse: `{"errors":{"client_order_id":["exists"]}}`},
+			expect: Expect{
+				errors:                map[string][]string{"client_order_id": {"exists"}},
+				duplicateClientOrder:  true,
+				isLiquidAlreadyExists: true,
+			},
+		},
+		// test case 2
+		{
+			param: Param{statusCode: http.StatusUnprocessableEntity, jsonResponse: `{"errors":{"user":["not_enough_free_balance"]}}`},
+			expect: Expect{
+				errors:              map[string][]string{"user": {"not_enough_free_balance"}},
+				insufficientBalance: true,
+			},
+		},
+		// test case 3
+		{
+			param:  Param{statusCode: http.StatusNotFound, jsonResponse: `{"message":"Not found"}`},
+			expect: Expect{message: "Not found", notFound: true},
+		},
+		// test case 4
+		{
+			param:  Param{statusCode: http.StatusUnauthorized, jsonResponse: `{"message":"Unauthorized"}`},
+			expect: Expect{message: "Unauthorized", unauthorized: true},
+		},
+		// test case 5
+		{
+			param:  Param{statusCode: http.StatusTooManyRequests, jsonResponse: `Too Many Requests`},
+			expect: Expect{rateLimited: true},
+		},
+		// test case 6
+		{
+			param:  Param{statusCode: http.StatusUnprocessableEntity, jsonResponse: `{"errors":{"order":["not_live"]}}`},
+			expect: Expect{errors: map[string][]string{"order": {"not_live"}}, orderNotLive: true},
+		},
+	}
+	for _, c := range cases {
+		ts := testutil.GenerateErrorTestServer(t, "/orders/1", "GET", c.param.statusCode, c.param.jsonResponse)
+		defer ts.Close()
+
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_, err := client.GetAnOrder(ctx, 1)
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Wrong error type. %#v", err)
+		}
+		if apiErr.StatusCode != c.param.statusCode || apiErr.Method != "GET" || apiErr.Path != "/orders/1" {
+			t.Errorf("Wrong attribute. %+v", apiErr)
+		}
+		if string(apiErr.Body) != c.param.jsonResponse {
+			t.Errorf("Wrong body. actual: %s, expect: %s", apiErr.Body, c.param.jsonResponse)
+		}
+		if !cmp.Equal(apiErr.Errors, c.expect.errors) {
+			t.Errorf("Wrong errors. %+v", cmp.Diff(apiErr.Errors, c.expect.errors))
+		}
+		if apiErr.Message != c.expect.message {
+			t.Errorf("Wrong message. actual: %s, expect: %s", apiErr.Message, c.expect.message)
+		}
+		if IsNotFound(err) != c.expect.notFound {
+			t.Errorf("Wrong IsNotFound. case: %+v", c)
+		}
+		if IsUnauthorized(err) != c.expect.unauthorized {
+			t.Errorf("Wrong IsUnauthorized. case: %+v", c)
+		}
+		if IsRateLimited(err) != c.expect.rateLimited {
+			t.Errorf("Wrong IsRateLimited. case: %+v", c)
+		}
+		if IsInsufficientBalance(err) != c.expect.insufficientBalance {
+			t.Errorf("Wrong IsInsufficientBalance. case: %+v", c)
+		}
+		if IsDuplicateClientOrderID(err) != c.expect.duplicateClientOrder {
+			t.Errorf("Wrong IsDuplicateClientOrderID. case: %+v", c)
+		}
+		if IsOrderNotLive(err) != c.expect.orderNotLive {
+			t.Errorf("Wrong IsOrderNotLive. case: %+v", c)
+		}
+		if errors.Is(err, LiquidAlreadyExistError) != c.expect.isLiquidAlreadyExists {
+			t.Errorf("Wrong errors.Is(LiquidAlreadyExistError). case: %+v", c)
+		}
+	}
+}
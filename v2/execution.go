@@ -5,30 +5,17 @@ import (
 	"fmt"
 	"github.com/sho3imo/quoinex-go-client/v2/models"
 	"strconv"
+	"time"
 )
 
-func (c *Client) GetExecutionsByTimestamp(ctx context.Context, productID int, limit int, timestamp int) ([]*models.ExecutionsModels, error) {
+func (c *Client) GetExecutionsByTimestamp(ctx context.Context, productID int, limit int, timestamp time.Time) ([]*models.ExecutionsModels, error) {
 	spath := fmt.Sprintf("/executions")
-	req, err := c.newRequest(ctx, "GET", spath, nil,
-		&map[string]string{
-			"product_id": strconv.Itoa(productID),
-			"limit":      strconv.Itoa(limit),
-			"timestamp":  strconv.Itoa(timestamp)})
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-
-	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("failed to get data. status: %s", res.Status)
-	}
-
+	queryParam := &map[string]string{
+		"product_id": strconv.Itoa(productID),
+		"limit":      strconv.Itoa(limit),
+		"timestamp":  strconv.FormatInt(timestamp.Unix(), 10)}
 	var executions []*models.ExecutionsModels
-	if err := decodeBody(res, &executions); err != nil {
+	if err := c.sendRequest(ctx, "GetExecutionsByTimestamp", "GET", spath, nil, queryParam, &executions); err != nil {
 		return nil, err
 	}
 
@@ -37,26 +24,12 @@ func (c *Client) GetExecutionsByTimestamp(ctx context.Context, productID int, li
 
 func (c *Client) GetExecutions(ctx context.Context, productID int, limit int, page int) (*models.Executions, error) {
 	spath := fmt.Sprintf("/executions")
-	req, err := c.newRequest(ctx, "GET", spath, nil,
-		&map[string]string{
-			"product_id": strconv.Itoa(productID),
-			"limit":      strconv.Itoa(limit),
-			"page":       strconv.Itoa(page)})
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-
-	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("failed to get data. status: %s", res.Status)
-	}
-
+	queryParam := &map[string]string{
+		"product_id": strconv.Itoa(productID),
+		"limit":      strconv.Itoa(limit),
+		"page":       strconv.Itoa(page)}
 	var executions models.Executions
-	if err := decodeBody(res, &executions); err != nil {
+	if err := c.sendRequest(ctx, "GetExecutions", "GET", spath, nil, queryParam, &executions); err != nil {
 		return nil, err
 	}
 
@@ -65,27 +38,50 @@ func (c *Client) GetExecutions(ctx context.Context, productID int, limit int, pa
 
 func (c *Client) GetOwnExecutions(ctx context.Context, productID int) (*models.Executions, error) {
 	spath := fmt.Sprintf("/executions/me")
-	req, err := c.newRequest(ctx, "GET", spath, nil,
-		&map[string]string{
-			"product_id": strconv.Itoa(productID),
-		})
-	if err != nil {
+	queryParam := &map[string]string{
+		"product_id": strconv.Itoa(productID)}
+	var executions models.Executions
+	if err := c.sendRequest(ctx, "GetOwnExecutions", "GET", spath, nil, queryParam, &executions); err != nil {
 		return nil, err
 	}
 
-	res, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
+	return &executions, nil
+}
 
-	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("failed to get data. status: %s", res.Status)
-	}
+// ExecutionFilter selects the executions walked by Executions and
+// OwnExecutions.
+type ExecutionFilter struct {
+	ProductID int
+	ListOptions
+}
 
-	var executions models.Executions
-	if err := decodeBody(res, &executions); err != nil {
-		return nil, err
-	}
+// ExecutionIterator walks executions one page at a time.
+type ExecutionIterator = Iterator[*models.ExecutionsModels]
 
-	return &executions, nil
+// Executions returns an iterator over the public executions of a product.
+func (c *Client) Executions(ctx context.Context, filter ExecutionFilter) *ExecutionIterator {
+	return c.executions(ctx, "GetExecutions", "/executions", filter)
+}
+
+// OwnExecutions returns an iterator over your own executions of a product.
+func (c *Client) OwnExecutions(ctx context.Context, filter ExecutionFilter) *ExecutionIterator {
+	return c.executions(ctx, "GetOwnExecutions", "/executions/me", filter)
+}
+
+// NewExecutionIterator returns an iterator that loads each page with fetch.
+func NewExecutionIterator(ctx context.Context, opts ListOptions, fetch func(ctx context.Context, page int) (*models.Executions, error)) *ExecutionIterator {
+	return newPageIterator(ctx, opts, fetch, func(p *models.Executions) ([]*models.ExecutionsModels, int) { return p.Models, p.TotalPages })
+}
+
+func (c *Client) executions(ctx context.Context, op, spath string, filter ExecutionFilter) *ExecutionIterator {
+	return NewExecutionIterator(ctx, filter.ListOptions, func(ctx context.Context, page int) (*models.Executions, error) {
+		queryParam := map[string]string{
+			"product_id": strconv.Itoa(filter.ProductID)}
+		filter.setQuery(queryParam, page)
+		var executions models.Executions
+		if err := c.sendRequest(ctx, op, "GET", spath, nil, &queryParam, &executions); err != nil {
+			return nil, err
+		}
+		return &executions, nil
+	})
 }
@@ -0,0 +1,258 @@
+package quoinex
+
+import (
+	"context"
+	"fmt"
+	"github.com/sho3imo/quoinex-go-client/v2/models"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+const defaultHistoryBatchSize = 1000
+
+// ExecutionCheckpoint records how far an execution history walk got. It can
+// be persisted as JSON and passed to HistoryCheckpoint to resume the walk.
+type ExecutionCheckpoint struct {
+	// Timestamp is the created_at of the last execution delivered.
+	Timestamp int64 `json:"timestamp"`
+	// IDs are the executions already delivered at Timestamp.
+	IDs []int `json:"ids"`
+}
+
+// HistoryOption configures ExecutionHistory.
+type HistoryOption func(*ExecutionHistoryIterator)
+
+// HistoryBatchSize sets how many executions are requested per call.
+func HistoryBatchSize(n int) HistoryOption {
+	return func(it *ExecutionHistoryIterator) {
+		if n > 0 {
+			it.limit = n
+		}
+	}
+}
+
+// HistoryCheckpoint resumes a walk after the executions recorded in cp.
+func HistoryCheckpoint(cp ExecutionCheckpoint) HistoryOption {
+	return func(it *ExecutionHistoryIterator) {
+		if cp.Timestamp < it.cursor {
+			return
+		}
+		it.cursor = cp.Timestamp
+		it.seen = make(map[int]bool, len(cp.IDs))
+		for _, id := range cp.IDs {
+			it.seen[id] = true
+		}
+	}
+}
+
+// ExecutionHistoryIterator streams every public execution of a product in
+// [from, to), oldest first.
+type ExecutionHistoryIterator struct {
+	fetch     ExecutionsByTimestampFunc
+	ctx       context.Context
+	productID int
+	to        int64
+	limit     int
+
+	cursor int64
+	seen   map[int]bool // ids delivered at cursor
+	buf    []*models.ExecutionsModels
+	cur    *models.ExecutionsModels
+	last   bool // the previous batch was short, so buf holds the tail
+	done   bool
+	err    error
+
+	quit      chan struct{} // closed by Close
+	closeOnce sync.Once
+	mu        sync.Mutex
+	received  *ExecutionCheckpoint // position after the last item Chan handed over
+}
+
+// HistoryItem is an execution sent by Chan with the checkpoint just after
+// it.
+type HistoryItem struct {
+	Execution  *models.ExecutionsModels
+	Checkpoint ExecutionCheckpoint
+}
+
+// ExecutionsByTimestampFunc has the signature of
+// Client.GetExecutionsByTimestamp.
+type ExecutionsByTimestampFunc func(ctx context.Context, productID int, limit int, timestamp time.Time) ([]*models.ExecutionsModels, error)
+
+// ExecutionHistory returns an iterator over the executions of productID
+// created in [from, to); a zero to has no upper bound. It walks GetExecutionsByTimestamp, advancing the
+// timestamp cursor and dropping executions already delivered when several
+// share a second. Requests go through the client's rate limiter.
+func (c *Client) ExecutionHistory(ctx context.Context, productID int, from, to time.Time, opts ...HistoryOption) *ExecutionHistoryIterator {
+	return NewExecutionHistoryIterator(ctx, c.GetExecutionsByTimestamp, productID, from, to, opts...)
+}
+
+// NewExecutionHistoryIterator is ExecutionHistory over any source of
+// executions by timestamp.
+func NewExecutionHistoryIterator(ctx context.Context, fetch ExecutionsByTimestampFunc, productID int, from, to time.Time, opts ...HistoryOption) *ExecutionHistoryIterator {
+	it := &ExecutionHistoryIterator{
+		fetch:     fetch,
+		ctx:       ctx,
+		productID: productID,
+		to:        math.MaxInt64,
+		limit:     defaultHistoryBatchSize,
+		cursor:    from.Unix(),
+		seen:      map[int]bool{},
+		quit:      make(chan struct{}),
+	}
+	if !to.IsZero() {
+		it.to = to.Unix()
+	}
+	for _, opt := range opts {
+		opt(it)
+	}
+	return it
+}
+
+// Next advances to the next execution. It returns false when the walk
+// reached to, caught up with the exchange or failed; check Err afterwards.
+func (it *ExecutionHistoryIterator) Next() bool {
+	it.cur = nil
+	if it.done {
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		return it.stop(err)
+	}
+	for len(it.buf) == 0 {
+		if it.last {
+			return it.stop(nil)
+		}
+		if err := it.fill(); err != nil {
+			return it.stop(err)
+		}
+	}
+
+	e := it.buf[0]
+	it.buf = it.buf[1:]
+	if e.CreatedAt.Unix() >= it.to {
+		return it.stop(nil)
+	}
+	if e.CreatedAt.Unix() > it.cursor {
+		it.cursor = e.CreatedAt.Unix()
+		it.seen = map[int]bool{}
+	}
+	it.seen[e.ID] = true
+	it.cur = e
+	return true
+}
+
+// fill loads the next batch at the cursor, keeping only executions that
+// have not been delivered yet.
+func (it *ExecutionHistoryIterator) fill() error {
+	batch, err := it.fetch(it.ctx, it.productID, it.limit, time.Unix(it.cursor, 0))
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(batch, func(i, j int) bool {
+		if !batch[i].CreatedAt.Equal(batch[j].CreatedAt.Time) {
+			return batch[i].CreatedAt.Before(batch[j].CreatedAt.Time)
+		}
+		return batch[i].ID < batch[j].ID
+	})
+
+	for _, e := range batch {
+		if e.CreatedAt.Unix() < it.cursor || (e.CreatedAt.Unix() == it.cursor && it.seen[e.ID]) {
+			continue
+		}
+		it.buf = append(it.buf, e)
+	}
+	it.last = len(batch) < it.limit
+	if len(it.buf) == 0 && !it.last {
+		// a full batch of executions we already have: more than limit
+		// executions share one second and the cursor cannot move past them
+		return fmt.Errorf("more than %d executions at timestamp %d; increase HistoryBatchSize", it.limit, it.cursor)
+	}
+	return nil
+}
+
+func (it *ExecutionHistoryIterator) stop(err error) bool {
+	it.done, it.err = true, err
+	return false
+}
+
+// Execution returns the current execution.
+func (it *ExecutionHistoryIterator) Execution() *models.ExecutionsModels {
+	return it.cur
+}
+
+// Err returns the error that stopped the walk, if any.
+func (it *ExecutionHistoryIterator) Err() error {
+	return it.err
+}
+
+// Checkpoint returns the position after the current execution. Once Chan
+// is called it returns the position after the last item received from the
+// channel, which can lag one item behind; HistoryItem.Checkpoint is exact.
+func (it *ExecutionHistoryIterator) Checkpoint() ExecutionCheckpoint {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	if it.received != nil {
+		return *it.received
+	}
+	return it.checkpoint()
+}
+
+func (it *ExecutionHistoryIterator) checkpoint() ExecutionCheckpoint {
+	cp := ExecutionCheckpoint{Timestamp: it.cursor}
+	for id := range it.seen {
+		cp.IDs = append(cp.IDs, id)
+	}
+	sort.Ints(cp.IDs)
+	return cp
+}
+
+// All drains the iterator. On error it returns the executions read so far.
+func (it *ExecutionHistoryIterator) All() ([]*models.ExecutionsModels, error) {
+	var all []*models.ExecutionsModels
+	for it.Next() {
+		all = append(all, it.Execution())
+	}
+	return all, it.Err()
+}
+
+// Chan streams the executions on a channel that is closed when the walk
+// ends. Check Err once the channel is closed. Each item carries the
+// checkpoint to resume after it, since the walk runs ahead of the consumer.
+//
+// A consumer that stops reading before then must call Close or cancel the
+// context; otherwise the goroutine sending on the channel never exits.
+func (it *ExecutionHistoryIterator) Chan() <-chan HistoryItem {
+	cp := it.checkpoint()
+	it.mu.Lock()
+	it.received = &cp
+	it.mu.Unlock()
+
+	ch := make(chan HistoryItem)
+	go func() {
+		defer close(ch)
+		for it.Next() {
+			item := HistoryItem{Execution: it.Execution(), Checkpoint: it.checkpoint()}
+			select {
+			case ch <- item:
+				it.mu.Lock()
+				it.received = &item.Checkpoint
+				it.mu.Unlock()
+			case <-it.quit:
+				return
+			case <-it.ctx.Done():
+				it.stop(it.ctx.Err())
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// Close stops the goroutine of Chan, which then closes its channel. It is
+// safe to call more than once.
+func (it *ExecutionHistoryIterator) Close() {
+	it.closeOnce.Do(func() { close(it.quit) })
+}
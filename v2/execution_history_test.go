@@ -0,0 +1,222 @@
+package quoinex
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sho3imo/quoinex-go-client/v2/models"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// historyServer answers GET /executions?timestamp=T&limit=N with the first N
+// executions created at or after T, the way the exchange does.
+func historyServer(t *testing.T) (*httptest.Server, func() []string) {
+	executions := []struct{ id, createdAt int }{
+		{1, 100}, {2, 100}, {3, 100}, {4, 101}, {5, 102}, {6, 102}, {7, 105},
+	}
+	var mu sync.Mutex
+	var seen []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		mu.Lock()
+		seen = append(seen, q.Get("timestamp"))
+		mu.Unlock()
+		if r.URL.Path != "/executions" || q.Get("product_id") != "1" {
+			t.Errorf("Wrong request. %s", r.URL.RequestURI())
+		}
+
+		timestamp, _ := strconv.Atoi(q.Get("timestamp"))
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		var items []string
+		for _, e := range executions {
+			if e.createdAt >= timestamp && len(items) < limit {
+				items = append(items, fmt.Sprintf(`{"id":%d,"quantity":"1","price":"100.5","taker_side":"buy","created_at":%d}`, e.id, e.createdAt))
+			}
+		}
+		w.Header().Set("content-Type", "application/json")
+		fmt.Fprintf(w, "[%s]", strings.Join(items, ","))
+	}))
+	return ts, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), seen...)
+	}
+}
+
+func TestExecutionHistory(t *testing.T) {
+	type Param struct {
+		from int64
+		to   int64 // 0 passes the zero time
+		opts []HistoryOption
+	}
+	type Expect struct {
+		ids        []int
+		timestamps []string
+		err        bool
+	}
+	cases := []struct {
+		param  Param
+		expect Expect
+	}{
+		// test case 1: executions sharing a second are delivered once
+		{
+			param:  Param{from: 100, to: 200, opts: []HistoryOption{HistoryBatchSize(4)}},
+			expect: Expect{ids: []int{1, 2, 3, 4, 5, 6, 7}, timestamps: []string{"100", "101", "105"}},
+		},
+		// test case 2: to is exclusive
+		{
+			param:  Param{from: 100, to: 102, opts: []HistoryOption{HistoryBatchSize(4)}},
+			expect: Expect{ids: []int{1, 2, 3, 4}, timestamps: []string{"100", "101"}},
+		},
+		// test case 3: resume from a checkpoint
+		{
+			param:  Param{from: 100, to: 200, opts: []HistoryOption{HistoryCheckpoint(ExecutionCheckpoint{Timestamp: 102, IDs: []int{5}})}},
+			expect: Expect{ids: []int{6, 7}, timestamps: []string{"102"}},
+		},
+		// test case 4: a checkpoint before from is ignored
+		{
+			param:  Param{from: 101, to: 200, opts: []HistoryOption{HistoryCheckpoint(ExecutionCheckpoint{Timestamp: 100, IDs: []int{1}})}},
+			expect: Expect{ids: []int{4, 5, 6, 7}, timestamps: []string{"101"}},
+		},
+		// test case 5: more executions in one second than a batch holds
+		{
+			param:  Param{from: 100, to: 200, opts: []HistoryOption{HistoryBatchSize(3)}},
+			expect: Expect{ids: []int{1, 2, 3}, timestamps: []string{"100", "100"}, err: true},
+		},
+		// test case 6: a zero to walks until the exchange runs out
+		{
+			param:  Param{from: 100, opts: []HistoryOption{HistoryBatchSize(4)}},
+			expect: Expect{ids: []int{1, 2, 3, 4, 5, 6, 7}, timestamps: []string{"100", "101", "105"}},
+		},
+	}
+	for _, c := range cases {
+		ts, timestamps := historyServer(t)
+		defer ts.Close()
+
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		to := time.Time{}
+		if c.param.to != 0 {
+			to = time.Unix(c.param.to, 0)
+		}
+		executions, err := client.ExecutionHistory(ctx, 1, time.Unix(c.param.from, 0), to, c.param.opts...).All()
+		if (err != nil) != c.expect.err {
+			t.Errorf("Wrong error. %+v", err)
+		}
+
+		var ids []int
+		for _, e := range executions {
+			ids = append(ids, e.ID)
+		}
+		if !cmp.Equal(ids, c.expect.ids) {
+			t.Errorf("Wrong ids. %+v", cmp.Diff(ids, c.expect.ids))
+		}
+		if !cmp.Equal(timestamps(), c.expect.timestamps) {
+			t.Errorf("Wrong cursor. %+v", cmp.Diff(timestamps(), c.expect.timestamps))
+		}
+	}
+}
+
+func TestExecutionHistoryCheckpointAndChan(t *testing.T) {
+	ts, _ := historyServer(t)
+	defer ts.Close()
+
+	client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	it := client.ExecutionHistory(ctx, 1, time.Unix(100, 0), time.Unix(200, 0), HistoryBatchSize(4))
+	for i := 0; i < 5; i++ {
+		it.Next()
+	}
+	expect := ExecutionCheckpoint{Timestamp: 102, IDs: []int{5}}
+	if !cmp.Equal(it.Checkpoint(), expect) {
+		t.Errorf("Wrong checkpoint. %+v", cmp.Diff(it.Checkpoint(), expect))
+	}
+
+	var ids []int
+	for item := range client.ExecutionHistory(ctx, 1, time.Unix(100, 0), time.Unix(200, 0), HistoryCheckpoint(it.Checkpoint())).Chan() {
+		ids = append(ids, item.Execution.ID)
+	}
+	if !cmp.Equal(ids, []int{6, 7}) {
+		t.Errorf("Wrong ids. %+v", ids)
+	}
+}
+
+func TestExecutionHistoryChanClose(t *testing.T) {
+	// an endless history: one execution per second
+	fetch := func(ctx context.Context, productID int, limit int, timestamp time.Time) ([]*models.ExecutionsModels, error) {
+		var executions []*models.ExecutionsModels
+		for i := 0; i < limit; i++ {
+			sec := timestamp.Unix() + int64(i)
+			executions = append(executions, &models.ExecutionsModels{ID: int(sec) - 99, CreatedAt: models.NewTimestampFromUnix(sec)})
+		}
+		return executions, nil
+	}
+	it := NewExecutionHistoryIterator(context.Background(), fetch, 1, time.Unix(100, 0), time.Unix(1<<40, 0), HistoryBatchSize(4))
+	ch := it.Chan()
+	if item := <-ch; item.Execution == nil || item.Execution.ID != 1 {
+		t.Fatalf("Wrong first execution. %+v", item)
+	}
+	// the consumer stops reading without cancelling the context
+	it.Close()
+	it.Close()
+
+	done := make(chan struct{})
+	go func() {
+		for range ch {
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Chan was not closed after Close")
+	}
+	if err := it.Err(); err != nil {
+		t.Errorf("Error. %+v", err)
+	}
+}
+
+func TestExecutionHistoryChanCheckpoint(t *testing.T) {
+	ts, _ := historyServer(t)
+	defer ts.Close()
+
+	client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	it := client.ExecutionHistory(ctx, 1, time.Unix(100, 0), time.Unix(200, 0), HistoryBatchSize(4))
+	start := it.Checkpoint()
+	prev := start
+	var items []HistoryItem
+	for item := range it.Chan() {
+		// the walk keeps running while the consumer reads the checkpoint
+		if cp := it.Checkpoint(); !cmp.Equal(cp, prev) && !cmp.Equal(cp, item.Checkpoint) {
+			t.Errorf("Checkpoint is ahead of execution %d. %+v", item.Execution.ID, cp)
+		}
+		items = append(items, item)
+		prev = item.Checkpoint
+	}
+	if len(items) != 7 {
+		t.Fatalf("Wrong number of executions. %d", len(items))
+	}
+	if !cmp.Equal(start, ExecutionCheckpoint{Timestamp: 100}) {
+		t.Errorf("Wrong starting checkpoint. %+v", start)
+	}
+
+	// resuming from an item's checkpoint continues right after it
+	for i, item := range items[:len(items)-1] {
+		next := client.ExecutionHistory(ctx, 1, time.Unix(100, 0), time.Unix(200, 0), HistoryCheckpoint(item.Checkpoint))
+		if !next.Next() || next.Execution().ID != items[i+1].Execution.ID {
+			t.Errorf("Wrong execution after %d. %+v", item.Execution.ID, next.Execution())
+		}
+	}
+}
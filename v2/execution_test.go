@@ -39,8 +39,7 @@ func TestGetExecutions(t *testing.T) {
 		ts := testutil.GenerateTestServer(t, c.expect.path, c.expect.method, c.expect.body, c.param.jsonResponse)
 		defer ts.Close()
 
-		client, _ := NewClient("apiTokenID", "secret", nil)
-		client.testServer = ts
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		executions, err := client.GetExecutions(ctx, c.param.productID, c.param.limit, c.param.page)
@@ -57,7 +56,7 @@ func TestGetExecutionsByTimestamp(t *testing.T) {
 	type Param struct {
 		productID    int
 		limit        int
-		timestamp    int
+		timestamp    time.Time
 		jsonResponse string
 	}
 	type Expect struct {
@@ -73,7 +72,7 @@ func TestGetExecutionsByTimestamp(t *testing.T) {
 	}{
 		// test case 1
 		{
-			param:  Param{productID: 1, limit: 2, timestamp: 1430630863, jsonResponse: testutil.GetExecutionsByTimestampJsonResponse()},
+			param:  Param{productID: 1, limit: 2, timestamp: time.Unix(1430630863, 0), jsonResponse: testutil.GetExecutionsByTimestampJsonResponse()},
 			expect: Expect{path: "/executions?limit=2&product_id=1&timestamp=1430630863", method: "GET", body: "", executions: testutil.GetExpectedExecutionsByTimestampModel()},
 		},
 		// test case 2
@@ -82,8 +81,7 @@ func TestGetExecutionsByTimestamp(t *testing.T) {
 		ts := testutil.GenerateTestServer(t, c.expect.path, c.expect.method, c.expect.body, c.param.jsonResponse)
 		defer ts.Close()
 
-		client, _ := NewClient("apiTokenID", "secret", nil)
-		client.testServer = ts
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		executions, err := client.GetExecutionsByTimestamp(ctx, c.param.productID, c.param.limit, c.param.timestamp)
@@ -122,8 +120,7 @@ func TestGetOwnExecutions(t *testing.T) {
 		ts := testutil.GenerateTestServer(t, c.expect.path, c.expect.method, c.expect.body, c.param.jsonResponse)
 		defer ts.Close()
 
-		client, _ := NewClient("apiTokenID", "secret", nil)
-		client.testServer = ts
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		executions, _ := client.GetOwnExecutions(ctx, c.param.productID)
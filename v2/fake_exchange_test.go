@@ -0,0 +1,489 @@
+package quoinex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sho3imo/quoinex-go-client/v2/models"
+	"github.com/sho3imo/quoinex-go-client/v2/testutil"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fixedNonceSource int64
+
+func (n fixedNonceSource) Nonce() int64 { return int64(n) }
+
+func TestFakeExchangeMatching(t *testing.T) {
+	fx := testutil.NewFakeExchange(t)
+	defer fx.Close()
+	client, _ := NewClient("apiTokenID", "secret", WithBaseURL(fx.URL))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ask1, err := client.CreateAnOrder(ctx, models.OrderTypeLimit, models.SideSell, models.MustDecimal("0.5"), models.MustDecimal("100.0"), models.Decimal{}, 1, "")
+	if err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	ask2, _ := client.CreateAnOrder(ctx, models.OrderTypeLimit, models.SideSell, models.MustDecimal("0.5"), models.MustDecimal("101.0"), models.Decimal{}, 1, "")
+	ask3, _ := client.CreateAnOrder(ctx, models.OrderTypeLimit, models.SideSell, models.MustDecimal("0.5"), models.MustDecimal("101.0"), models.Decimal{}, 1, "")
+	bid, err := client.CreateAnOrder(ctx, models.OrderTypeLimit, models.SideBuy, models.MustDecimal("0.8"), models.MustDecimal("101.0"), models.Decimal{}, 1, "")
+	if err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	if bid.Status != models.OrderStatusFilled || len(bid.Executions) != 2 {
+		t.Errorf("Wrong taker. status: %s, executions: %d", bid.Status, len(bid.Executions))
+	}
+
+	type Expect struct {
+		status models.OrderStatus
+		filled string
+	}
+	cases := []struct {
+		id     int
+		expect Expect
+	}{
+		// test case 1: best price first
+		{id: ask1.ID, expect: Expect{status: models.OrderStatusFilled, filled: "0.5"}},
+		// test case 2: earlier order first at the same price
+		{id: ask2.ID, expect: Expect{status: models.OrderStatusLive, filled: "0.3"}},
+		// test case 3
+		{id: ask3.ID, expect: Expect{status: models.OrderStatusLive, filled: "0"}},
+	}
+	for _, c := range cases {
+		order, err := client.GetAnOrder(ctx, c.id)
+		if err != nil {
+			t.Fatalf("Error. %+v", err)
+		}
+		if order.Status != c.expect.status || !order.FilledQuantity.Equal(models.MustDecimal(c.expect.filled)) {
+			t.Errorf("Wrong order %d. status: %s, filled: %s", c.id, order.Status, order.FilledQuantity)
+		}
+	}
+
+	book, err := client.GetOrderBook(ctx, 1, false)
+	if err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	if len(book.BuyPriceLevels) != 0 || len(book.SellPriceLevels) != 1 || !book.SellPriceLevels[0][1].Equal(models.MustDecimal("0.7")) {
+		t.Errorf("Wrong order book. %+v", book)
+	}
+	product, _ := client.GetProduct(ctx, 1)
+	if !product.LastTradedPrice.Equal(models.MustDecimal("101")) || !product.MarketAsk.Equal(models.MustDecimal("101")) {
+		t.Errorf("Wrong product. last: %s, ask: %s", product.LastTradedPrice, product.MarketAsk)
+	}
+	executions, _ := client.GetExecutions(ctx, 1, 20, 1)
+	if len(executions.Models) != 2 || !executions.Models[0].Price.Equal(models.MustDecimal("101")) {
+		t.Errorf("Wrong executions. %+v", executions.Models)
+	}
+
+	// an iceberg order shows only its slice
+	if _, err := client.CreateOrder(ctx, &CreateOrderRequest{OrderType: models.OrderTypeLimit, ProductID: 1, Side: models.SideBuy,
+		Quantity: models.MustDecimal("5"), Price: decimalPtr("90"), DiscQuantity: decimalPtr("0.5")}); err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	book, _ = client.GetOrderBook(ctx, 1, false)
+	if len(book.BuyPriceLevels) != 1 || !book.BuyPriceLevels[0][1].Equal(models.MustDecimal("0.5")) {
+		t.Errorf("Wrong iceberg level. %+v", book.BuyPriceLevels)
+	}
+
+	// the rest of a market order is cancelled
+	market, _ := client.CreateAnOrder(ctx, models.OrderTypeMarket, models.SideBuy, models.MustDecimal("1.0"), models.Decimal{}, models.Decimal{}, 1, "")
+	if market.Status != models.OrderStatusCancelled || !market.FilledQuantity.Equal(models.MustDecimal("0.7")) {
+		t.Errorf("Wrong market order. status: %s, filled: %s", market.Status, market.FilledQuantity)
+	}
+}
+
+func TestFakeExchangeOrderLifecycle(t *testing.T) {
+	fx := testutil.NewFakeExchange(t)
+	defer fx.Close()
+	client, _ := NewClient("apiTokenID", "secret", WithBaseURL(fx.URL))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	order, err := client.CreateAnOrder(ctx, models.OrderTypeLimit, models.SideBuy, models.MustDecimal("0.01"), models.MustDecimal("500.0"), models.Decimal{}, 1, "bot-1")
+	if err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	if _, err := client.CreateAnOrder(ctx, models.OrderTypeLimit, models.SideBuy, models.MustDecimal("0.01"), models.MustDecimal("500.0"), models.Decimal{}, 1, "bot-1"); !errors.Is(err, LiquidAlreadyExistError) {
+		t.Errorf("Wrong duplicate error. %+v", err)
+	}
+	edited, err := client.EditALiveOrder(ctx, order.ID, models.MustDecimal("0.02"), models.MustDecimal("510.0"))
+	if err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	if !edited.Quantity.Equal(models.MustDecimal("0.02")) || !edited.Price.Equal(models.MustDecimal("510")) {
+		t.Errorf("Wrong edited order. %+v", edited)
+	}
+	if got, err := client.GetOrderByClientID(ctx, "bot-1"); err != nil || got.ID != order.ID {
+		t.Errorf("Wrong order by client id. %+v, %+v", got, err)
+	}
+	if _, err := client.EditOrderByClientID(ctx, "bot-1", &EditOrderRequest{Price: decimalPtr("505.0")}); err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	if _, err := client.CancelOrderByClientID(ctx, "bot-1"); err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	if _, err := client.GetOrderByClientID(ctx, "bot-2"); !IsNotFound(err) {
+		t.Errorf("Wrong error for a missing client order id. %+v", err)
+	}
+	if _, err := client.CancelAnOrder(ctx, order.ID); err == nil || IsNotFound(err) {
+		t.Errorf("Wrong error cancelling a cancelled order. %+v", err)
+	}
+	if _, err := client.GetAnOrder(ctx, 1); !IsNotFound(err) {
+		t.Errorf("Wrong error for a missing order. %+v", err)
+	}
+	if got := fx.Order(order.ID); got == nil || got.Status != models.OrderStatusCancelled {
+		t.Errorf("Wrong exchange state. %+v", got)
+	}
+}
+
+func TestFakeExchangeClientOrderIDEscaping(t *testing.T) {
+	type Param struct {
+		clientOrderID string
+	}
+	type Expect struct {
+		requests []string
+	}
+	cases := []struct {
+		param  Param
+		expect Expect
+	}{
+		// test case 1
+		{
+			param: Param{clientOrderID: "bot 1"},
+			expect: Expect{requests: []string{
+				"GET /orders/client:bot%201",
+				"PUT /orders/client:bot%201",
+				"PUT /orders/client:bot%201/cancel",
+			}},
+		},
+		// test case 2: an escaped slash stays in its segment
+		{
+			param: Param{clientOrderID: "a/b"},
+			expect: Expect{requests: []string{
+				"GET /orders/client:a%2Fb",
+				"PUT /orders/client:a%2Fb",
+				"PUT /orders/client:a%2Fb/cancel",
+			}},
+		},
+		// test case 3
+		{
+			param: Param{clientOrderID: "50%?#x"},
+			expect: Expect{requests: []string{
+				"GET /orders/client:50%25%3F%23x",
+				"PUT /orders/client:50%25%3F%23x",
+				"PUT /orders/client:50%25%3F%23x/cancel",
+			}},
+		},
+	}
+	for _, c := range cases {
+		fx := testutil.NewFakeExchange(t)
+		defer fx.Close()
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(fx.URL))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		order, err := client.CreateAnOrder(ctx, models.OrderTypeLimit, models.SideBuy, models.MustDecimal("0.01"), models.MustDecimal("500.0"), models.Decimal{}, 1, c.param.clientOrderID)
+		if err != nil {
+			t.Fatalf("Error. %+v", err)
+		}
+		if got, err := client.GetOrderByClientID(ctx, c.param.clientOrderID); err != nil || got.ID != order.ID {
+			t.Errorf("Wrong order by client id. %+v, %+v", got, err)
+		}
+		if _, err := client.EditOrderByClientID(ctx, c.param.clientOrderID, &EditOrderRequest{Price: decimalPtr("505.0")}); err != nil {
+			t.Errorf("Error. %+v", err)
+		}
+		if _, err := client.CancelOrderByClientID(ctx, c.param.clientOrderID); err != nil {
+			t.Errorf("Error. %+v", err)
+		}
+		// the requests after the create
+		requests := fx.Requests()[1:]
+		if !cmp.Equal(requests, c.expect.requests) {
+			t.Errorf("Wrong requests. %+v", cmp.Diff(requests, c.expect.requests))
+		}
+	}
+}
+
+func TestFakeExchangeOrderTypes(t *testing.T) {
+	type Param struct {
+		req CreateOrderRequest
+	}
+	type Expect struct {
+		price             string
+		discQuantity      string
+		trailingStopType  models.TrailingStopType
+		trailingStopValue string
+	}
+	qty := models.MustDecimal("0.5")
+	cases := []struct {
+		param  Param
+		expect Expect
+	}{
+		// test case 1: the price is the stop trigger
+		{
+			param:  Param{req: CreateOrderRequest{OrderType: models.OrderTypeStop, ProductID: 1, Side: models.SideBuy, Quantity: qty, Price: decimalPtr("550.0")}},
+			expect: Expect{price: "550", discQuantity: "0"},
+		},
+		// test case 2
+		{
+			param: Param{req: CreateOrderRequest{OrderType: models.OrderTypeTrailingStop, ProductID: 1, Side: models.SideBuy, Quantity: qty,
+				TrailingStopType: models.TrailingStopTypePercentage, TrailingStopValue: decimalPtr("2.5")}},
+			expect: Expect{price: "0", discQuantity: "0", trailingStopType: models.TrailingStopTypePercentage, trailingStopValue: "2.5"},
+		},
+		// test case 3: an iceberg order
+		{
+			param:  Param{req: CreateOrderRequest{OrderType: models.OrderTypeLimit, ProductID: 1, Side: models.SideBuy, Quantity: qty, Price: decimalPtr("450.0"), DiscQuantity: decimalPtr("0.1")}},
+			expect: Expect{price: "450", discQuantity: "0.1"},
+		},
+	}
+	for i, c := range cases {
+		fx := testutil.NewFakeExchange(t)
+		defer fx.Close()
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(fx.URL))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		// a resting ask the stop orders would cross if they matched
+		if _, err := client.CreateAnOrder(ctx, models.OrderTypeLimit, models.SideSell, qty, models.MustDecimal("500.0"), models.Decimal{}, 1, ""); err != nil {
+			t.Fatalf("Error in case %d. %+v", i+1, err)
+		}
+		created, err := client.CreateOrder(ctx, &c.param.req)
+		if err != nil {
+			t.Fatalf("Error in case %d. %+v", i+1, err)
+		}
+		got, err := client.GetAnOrder(ctx, created.ID)
+		if err != nil {
+			t.Fatalf("Error in case %d. %+v", i+1, err)
+		}
+		if got.OrderType != c.param.req.OrderType || got.Status != models.OrderStatusLive || !got.FilledQuantity.IsZero() {
+			t.Errorf("Wrong order in case %d. type: %s, status: %s, filled: %s", i+1, got.OrderType, got.Status, got.FilledQuantity)
+		}
+		if !got.Price.Equal(models.MustDecimal(c.expect.price)) || !got.DiscQuantity.Equal(models.MustDecimal(c.expect.discQuantity)) {
+			t.Errorf("Wrong price in case %d. price: %s, disc_quantity: %s", i+1, got.Price, got.DiscQuantity)
+		}
+		if c.expect.trailingStopType != "" && (got.TrailingStopType != c.expect.trailingStopType || !got.TrailingStopValue.Equal(models.MustDecimal(c.expect.trailingStopValue))) {
+			t.Errorf("Wrong trailing stop in case %d. %s %s", i+1, got.TrailingStopType, got.TrailingStopValue)
+		}
+	}
+}
+
+func TestFakeExchangeMarketWithRange(t *testing.T) {
+	type Param struct {
+		side       models.Side
+		price      string
+		priceRange string
+	}
+	type Expect struct {
+		filled string
+	}
+	cases := []struct {
+		param  Param
+		expect Expect
+	}{
+		// test case 1: the 520 level is outside 500 ± 10
+		{param: Param{side: models.SideBuy, price: "500", priceRange: "10"}, expect: Expect{filled: "0.6"}},
+		// test case 2: without a price the range is from the best price
+		{param: Param{side: models.SideBuy, price: "0", priceRange: "4"}, expect: Expect{filled: "0.3"}},
+		// test case 3
+		{param: Param{side: models.SideSell, price: "490", priceRange: "30"}, expect: Expect{filled: "0.9"}},
+		// test case 4
+		{param: Param{side: models.SideSell, price: "490", priceRange: "5"}, expect: Expect{filled: "0.3"}},
+	}
+	for i, c := range cases {
+		fx := testutil.NewFakeExchange(t)
+		defer fx.Close()
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(fx.URL))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		// three levels on the side the order takes from
+		makerSide, prices := models.SideSell, []string{"500", "505", "520"}
+		if c.param.side == models.SideSell {
+			makerSide, prices = models.SideBuy, []string{"490", "470", "460"}
+		}
+		for _, p := range prices {
+			if _, err := client.CreateAnOrder(ctx, models.OrderTypeLimit, makerSide, models.MustDecimal("0.3"), models.MustDecimal(p), models.Decimal{}, 1, ""); err != nil {
+				t.Fatalf("Error in case %d. %+v", i+1, err)
+			}
+		}
+		order, err := client.CreateAnOrder(ctx, models.OrderTypeMarketWithRange, c.param.side, models.MustDecimal("1.0"),
+			models.MustDecimal(c.param.price), models.MustDecimal(c.param.priceRange), 1, "")
+		if err != nil {
+			t.Fatalf("Error in case %d. %+v", i+1, err)
+		}
+		if order.Status != models.OrderStatusCancelled || !order.FilledQuantity.Equal(models.MustDecimal(c.expect.filled)) {
+			t.Errorf("Wrong order in case %d. status: %s, filled: %s", i+1, order.Status, order.FilledQuantity)
+		}
+	}
+}
+
+func TestFakeExchangePagination(t *testing.T) {
+	fx := testutil.NewFakeExchange(t)
+	defer fx.Close()
+	fx.PageSize = 2
+	client, _ := NewClient("apiTokenID", "secret", WithBaseURL(fx.URL))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	for i := 0; i < 5; i++ {
+		if _, err := client.CreateAnOrder(ctx, models.OrderTypeLimit, models.SideBuy, models.MustDecimal("0.01"), models.NewDecimalFromInt(int64(100+i)), models.Decimal{}, 1, ""); err != nil {
+			t.Fatalf("Error. %+v", err)
+		}
+	}
+	orders, err := client.Orders(ctx, OrderFilter{ProductID: 1, Status: models.OrderStatusLive}).All()
+	if err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	if len(orders) != 5 || !orders[0].Price.Equal(models.NewDecimalFromInt(104)) {
+		t.Errorf("Wrong orders. %d", len(orders))
+	}
+	pages := 0
+	for _, r := range fx.Requests() {
+		if strings.HasPrefix(r, "GET /orders?") {
+			pages++
+		}
+	}
+	if pages != 3 {
+		t.Errorf("Wrong number of page requests. %d", pages)
+	}
+}
+
+func TestFakeExchangeTradingTypeFilter(t *testing.T) {
+	type Param struct {
+		tradingType models.TradingType
+	}
+	type Expect struct {
+		prices []string
+	}
+	cases := []struct {
+		param  Param
+		expect Expect
+	}{
+		// test case 1
+		{param: Param{tradingType: models.TradingTypeSpot}, expect: Expect{prices: []string{"102", "100"}}},
+		// test case 2
+		{param: Param{tradingType: models.TradingTypeMargin}, expect: Expect{prices: []string{"103", "101"}}},
+		// test case 3: no filter
+		{param: Param{}, expect: Expect{prices: []string{"103", "102", "101", "100"}}},
+	}
+	fx := testutil.NewFakeExchange(t)
+	defer fx.Close()
+	client, _ := NewClient("apiTokenID", "secret", WithBaseURL(fx.URL))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// spot and margin orders alternate
+	for i := 0; i < 4; i++ {
+		req := &CreateOrderRequest{OrderType: models.OrderTypeLimit, ProductID: 1, Side: models.SideBuy,
+			Quantity: models.MustDecimal("0.01"), Price: decimalPtr(fmt.Sprintf("%d", 100+i))}
+		if i%2 == 1 {
+			req.TradingType, req.LeverageLevel = models.TradingTypeMargin, 2
+		}
+		if _, err := client.CreateOrder(ctx, req); err != nil {
+			t.Fatalf("Error. %+v", err)
+		}
+	}
+	for i, c := range cases {
+		orders, err := client.Orders(ctx, OrderFilter{ProductID: 1, TradingType: c.param.tradingType}).All()
+		if err != nil {
+			t.Fatalf("Error in case %d. %+v", i+1, err)
+		}
+		var prices []string
+		for _, o := range orders {
+			if c.param.tradingType != "" && o.TradingType != c.param.tradingType {
+				t.Errorf("Wrong trading type in case %d. %s", i+1, o.TradingType)
+			}
+			prices = append(prices, o.Price.String())
+		}
+		if !cmp.Equal(prices, c.expect.prices) {
+			t.Errorf("Wrong orders in case %d. %+v", i+1, cmp.Diff(prices, c.expect.prices))
+		}
+	}
+}
+
+func TestFakeExchangeAuthentication(t *testing.T) {
+	fx := testutil.NewFakeExchange(t)
+	defer fx.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	type Param struct {
+		secret string
+		nonce  NonceSource
+	}
+	cases := []struct {
+		param  Param
+		expect bool
+	}{
+		// test case 1
+		{param: Param{secret: "secret"}, expect: false},
+		// test case 2
+		{param: Param{secret: "wrong"}, expect: true},
+		// test case 3: the nonce was already used
+		{param: Param{secret: "secret", nonce: fixedNonceSource(1)}, expect: true},
+	}
+	for _, c := range cases {
+		opts := []ClientOption{WithBaseURL(fx.URL)}
+		if c.param.nonce != nil {
+			opts = append(opts, WithNonceSource(c.param.nonce))
+		}
+		client, _ := NewClient("apiTokenID", c.param.secret, opts...)
+		_, err := client.GetFiatAccounts(ctx)
+		if IsUnauthorized(err) != c.expect {
+			t.Errorf("Wrong error. secret: %s, err: %+v", c.param.secret, err)
+		}
+	}
+}
+
+func TestFakeExchangeNonceWindow(t *testing.T) {
+	fx := testutil.NewFakeExchange(t)
+	defer fx.Close()
+	fx.NonceWindow = 2
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cases := []struct {
+		nonce  int64
+		expect bool
+	}{
+		// test case 1
+		{nonce: 10, expect: false},
+		// test case 2
+		{nonce: 13, expect: false},
+		// test case 3: late, within the window
+		{nonce: 12, expect: false},
+		// test case 4
+		{nonce: 12, expect: true},
+		// test case 5
+		{nonce: 11, expect: false},
+		// test case 6: older than the window
+		{nonce: 10, expect: true},
+	}
+	for _, c := range cases {
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(fx.URL), WithNonceSource(fixedNonceSource(c.nonce)))
+		_, err := client.GetFiatAccounts(ctx)
+		if IsUnauthorized(err) != c.expect {
+			t.Errorf("Wrong error. nonce: %d, err: %+v", c.nonce, err)
+		}
+	}
+}
+
+func TestFakeExchangeInjectedFailure(t *testing.T) {
+	fx := testutil.NewFakeExchange(t)
+	defer fx.Close()
+	fx.InjectFailure(testutil.Failure{Method: "GET", Path: "/products", StatusCode: 503, Times: 2})
+	client, _ := NewClient("apiTokenID", "secret", WithBaseURL(fx.URL),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	products, err := client.GetProducts(ctx)
+	if err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	if len(products) != 2 || len(fx.Requests()) != 3 {
+		t.Errorf("Wrong result. products: %d, requests: %v", len(products), fx.Requests())
+	}
+}
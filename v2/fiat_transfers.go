@@ -0,0 +1,198 @@
+package quoinex
+
+import (
+	"context"
+	"fmt"
+	"github.com/sho3imo/quoinex-go-client/v2/models"
+)
+
+// GetFiatDeposits returns one page of the fiat deposits matching filter:
+// the page of filter.Page, or the first. Use FiatDeposits to walk every page.
+func (c *Client) GetFiatDeposits(ctx context.Context, filter FiatDepositFilter) (*models.FiatDeposits, error) {
+	if err := filter.State.Validate(); err != nil {
+		return nil, err
+	}
+	spath := fmt.Sprintf("/fiat_deposits")
+	queryParam := filter.query()
+	filter.setPageQuery(queryParam)
+	var deposits models.FiatDeposits
+	if err := c.sendRequest(ctx, "GetFiatDeposits", "GET", spath, nil, &queryParam, &deposits); err != nil {
+		return nil, err
+	}
+
+	return &deposits, nil
+}
+
+// FiatDepositFilter selects the deposits of GetFiatDeposits and
+// FiatDeposits. Zero fields are not sent.
+type FiatDepositFilter struct {
+	Currency string
+	State    models.DepositState
+	ListOptions
+}
+
+func (f FiatDepositFilter) query() map[string]string {
+	return map[string]string{
+		"currency": f.Currency,
+		"state":    string(f.State)}
+}
+
+// FiatDepositIterator walks every fiat deposit matching a filter, one page at a time.
+type FiatDepositIterator = Iterator[*models.FiatDeposit]
+
+// NewFiatDepositIterator returns an iterator that loads each page with fetch.
+func NewFiatDepositIterator(ctx context.Context, opts ListOptions, fetch func(ctx context.Context, page int) (*models.FiatDeposits, error)) *FiatDepositIterator {
+	return newPageIterator(ctx, opts, fetch, func(p *models.FiatDeposits) ([]*models.FiatDeposit, int) { return p.Models, p.TotalPages })
+}
+
+// FiatDeposits returns an iterator over every fiat deposit matching filter.
+func (c *Client) FiatDeposits(ctx context.Context, filter FiatDepositFilter) *FiatDepositIterator {
+	it := NewFiatDepositIterator(ctx, filter.ListOptions, func(ctx context.Context, page int) (*models.FiatDeposits, error) {
+		queryParam := filter.query()
+		filter.setQuery(queryParam, page)
+		var deposits models.FiatDeposits
+		if err := c.sendRequest(ctx, "GetFiatDeposits", "GET", "/fiat_deposits", nil, &queryParam, &deposits); err != nil {
+			return nil, err
+		}
+		return &deposits, nil
+	})
+	if err := filter.State.Validate(); err != nil {
+		it.stop(err)
+	}
+	return it
+}
+
+// CreateFiatWithdrawal requests a withdrawal to a registered bank account.
+func (c *Client) CreateFiatWithdrawal(ctx context.Context, req *FiatWithdrawalRequest) (*models.FiatWithdrawal, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request is nil")
+	}
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+	spath := fmt.Sprintf("/fiat_withdrawals")
+	body, err := jsonBody(&fiatWithdrawalEnvelope{AuthCode: req.AuthCode, FiatWithdrawal: req})
+	if err != nil {
+		return nil, err
+	}
+	var withdrawal models.FiatWithdrawal
+	if err := c.sendRequest(ctx, "CreateFiatWithdrawal", "POST", spath, body, nil, &withdrawal); err != nil {
+		return nil, err
+	}
+
+	return &withdrawal, nil
+}
+
+// GetFiatWithdrawals returns one page of the fiat withdrawals matching
+// filter: the page of filter.Page, or the first. Use FiatWithdrawals to
+// walk every page.
+func (c *Client) GetFiatWithdrawals(ctx context.Context, filter FiatWithdrawalFilter) (*models.FiatWithdrawals, error) {
+	if err := filter.State.Validate(); err != nil {
+		return nil, err
+	}
+	spath := fmt.Sprintf("/fiat_withdrawals")
+	queryParam := filter.query()
+	filter.setPageQuery(queryParam)
+	var withdrawals models.FiatWithdrawals
+	if err := c.sendRequest(ctx, "GetFiatWithdrawals", "GET", spath, nil, &queryParam, &withdrawals); err != nil {
+		return nil, err
+	}
+
+	return &withdrawals, nil
+}
+
+// FiatWithdrawalFilter selects the withdrawals of GetFiatWithdrawals and
+// FiatWithdrawals. Zero fields are not sent.
+type FiatWithdrawalFilter struct {
+	Currency string
+	State    models.WithdrawalState
+	ListOptions
+}
+
+func (f FiatWithdrawalFilter) query() map[string]string {
+	return map[string]string{
+		"currency": f.Currency,
+		"state":    string(f.State)}
+}
+
+// FiatWithdrawalIterator walks every fiat withdrawal matching a filter, one page at a time.
+type FiatWithdrawalIterator = Iterator[*models.FiatWithdrawal]
+
+// NewFiatWithdrawalIterator returns an iterator that loads each page with fetch.
+func NewFiatWithdrawalIterator(ctx context.Context, opts ListOptions, fetch func(ctx context.Context, page int) (*models.FiatWithdrawals, error)) *FiatWithdrawalIterator {
+	return newPageIterator(ctx, opts, fetch, func(p *models.FiatWithdrawals) ([]*models.FiatWithdrawal, int) { return p.Models, p.TotalPages })
+}
+
+// FiatWithdrawals returns an iterator over every fiat withdrawal matching
+// filter.
+func (c *Client) FiatWithdrawals(ctx context.Context, filter FiatWithdrawalFilter) *FiatWithdrawalIterator {
+	it := NewFiatWithdrawalIterator(ctx, filter.ListOptions, func(ctx context.Context, page int) (*models.FiatWithdrawals, error) {
+		queryParam := filter.query()
+		filter.setQuery(queryParam, page)
+		var withdrawals models.FiatWithdrawals
+		if err := c.sendRequest(ctx, "GetFiatWithdrawals", "GET", "/fiat_withdrawals", nil, &queryParam, &withdrawals); err != nil {
+			return nil, err
+		}
+		return &withdrawals, nil
+	})
+	if err := filter.State.Validate(); err != nil {
+		it.stop(err)
+	}
+	return it
+}
+
+// CancelFiatWithdrawal cancels a pending withdrawal.
+func (c *Client) CancelFiatWithdrawal(ctx context.Context, withdrawalID int) (*models.FiatWithdrawal, error) {
+	spath := fmt.Sprintf("/fiat_withdrawals/%d/cancel", withdrawalID)
+	var withdrawal models.FiatWithdrawal
+	if err := c.sendRequest(ctx, "CancelFiatWithdrawal", "PUT", spath, nil, nil, &withdrawal); err != nil {
+		return nil, err
+	}
+
+	return &withdrawal, nil
+}
+
+// GetBankAccounts returns one page of the registered bank accounts
+// matching filter. Use BankAccounts to walk every page.
+func (c *Client) GetBankAccounts(ctx context.Context, filter BankAccountFilter) (*models.BankAccounts, error) {
+	spath := fmt.Sprintf("/bank_accounts")
+	queryParam := map[string]string{
+		"currency": filter.Currency}
+	filter.setPageQuery(queryParam)
+	var accounts models.BankAccounts
+	if err := c.sendRequest(ctx, "GetBankAccounts", "GET", spath, nil, &queryParam, &accounts); err != nil {
+		return nil, err
+	}
+
+	return &accounts, nil
+}
+
+// BankAccountFilter selects the bank accounts of GetBankAccounts and
+// BankAccounts.
+type BankAccountFilter struct {
+	Currency string
+	ListOptions
+}
+
+// BankAccountIterator walks every bank account matching a filter, one page at a time.
+type BankAccountIterator = Iterator[*models.BankAccount]
+
+// NewBankAccountIterator returns an iterator that loads each page with fetch.
+func NewBankAccountIterator(ctx context.Context, opts ListOptions, fetch func(ctx context.Context, page int) (*models.BankAccounts, error)) *BankAccountIterator {
+	return newPageIterator(ctx, opts, fetch, func(p *models.BankAccounts) ([]*models.BankAccount, int) { return p.Models, p.TotalPages })
+}
+
+// BankAccounts returns an iterator over every registered bank account
+// matching filter.
+func (c *Client) BankAccounts(ctx context.Context, filter BankAccountFilter) *BankAccountIterator {
+	return NewBankAccountIterator(ctx, filter.ListOptions, func(ctx context.Context, page int) (*models.BankAccounts, error) {
+		queryParam := map[string]string{
+			"currency": filter.Currency}
+		filter.setQuery(queryParam, page)
+		var accounts models.BankAccounts
+		if err := c.sendRequest(ctx, "GetBankAccounts", "GET", "/bank_accounts", nil, &queryParam, &accounts); err != nil {
+			return nil, err
+		}
+		return &accounts, nil
+	})
+}
@@ -0,0 +1,125 @@
+package quoinex
+
+import (
+	"context"
+	"github.com/sho3imo/quoinex-go-client/v2/models"
+	"github.com/sho3imo/quoinex-go-client/v2/testutil"
+	"testing"
+	"time"
+)
+
+func TestCreateFiatWithdrawal(t *testing.T) {
+	type Param struct {
+		req *FiatWithdrawalRequest
+	}
+	type Expect struct {
+		body string
+		err  bool
+	}
+	cases := []struct {
+		param  Param
+		expect Expect
+	}{
+		// test case 1
+		{param: Param{req: &FiatWithdrawalRequest{Currency: "USD", Amount: models.MustDecimal("100.5"), BankAccountID: 3, AuthCode: "123456"}},
+			expect: Expect{body: `{"auth_code":"123456","fiat_withdrawal":{"currency":"USD","amount":"100.5","bank_id":3}}`}},
+		// test case 2
+		{param: Param{req: &FiatWithdrawalRequest{Currency: "USD", Amount: models.MustDecimal("100")}}, expect: Expect{err: true}},
+		// test case 3
+		{param: Param{req: &FiatWithdrawalRequest{Currency: "USD", BankAccountID: 3}}, expect: Expect{err: true}},
+		// test case 4
+		{param: Param{req: nil}, expect: Expect{err: true}},
+	}
+	for i, c := range cases {
+		s := testutil.NewServer(t)
+		if c.expect.body != "" {
+			s.Expect("POST", "/fiat_withdrawals").WithJSONBody(c.expect.body).Respond(200, `{"id":21,"currency":"USD","amount":"100.5","state":"pending","bank_id":3}`)
+		}
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(s.URL))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		withdrawal, err := client.CreateFiatWithdrawal(ctx, c.param.req)
+		cancel()
+		if (err != nil) != c.expect.err {
+			t.Errorf("Wrong error in case %d. %+v", i+1, err)
+		}
+		if err == nil && (withdrawal.ID != 21 || withdrawal.BankAccountID != 3 || withdrawal.State != models.WithdrawalStatePending) {
+			t.Errorf("Wrong withdrawal in case %d. %+v", i+1, withdrawal)
+		}
+		if err := s.Verify(); err != nil {
+			t.Errorf("Error in case %d. %+v", i+1, err)
+		}
+		s.Close()
+	}
+}
+
+func TestFiatTransfers(t *testing.T) {
+	fx := testutil.NewFakeExchange(t)
+	defer fx.Close()
+	fx.PageSize = 2
+	fx.AddBankAccount(&models.BankAccount{ID: 3, Currency: "USD", BankName: "Bank of Example", AccountNumber: "0001"})
+	fx.AddBankAccount(&models.BankAccount{ID: 4, Currency: "USD", BankName: "Second Bank", AccountNumber: "0002"})
+	fx.AddBankAccount(&models.BankAccount{ID: 6, Currency: "JPY", BankName: "Yen Bank", AccountNumber: "0003"})
+	for i, state := range []models.DepositState{models.DepositStateProcessed, models.DepositStatePending, models.DepositStateProcessed} {
+		fx.AddFiatDeposit(&models.FiatDeposit{ID: 100 + i, Currency: "USD", Amount: models.MustDecimal("500"), State: state})
+	}
+	client, _ := NewClient("apiTokenID", "secret", WithBaseURL(fx.URL))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	banks, err := client.BankAccounts(ctx, BankAccountFilter{Currency: "USD"}).All()
+	if err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	if len(banks) != 2 || banks[1].BankName != "Second Bank" {
+		t.Errorf("Wrong bank accounts. %+v", banks)
+	}
+	deposits, err := client.FiatDeposits(ctx, FiatDepositFilter{Currency: "USD", State: models.DepositStateProcessed}).All()
+	if err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	if len(deposits) != 2 || deposits[0].ID != 102 || deposits[1].ID != 100 {
+		t.Errorf("Wrong deposits. %+v", deposits)
+	}
+
+	first, err := client.CreateFiatWithdrawal(ctx, &FiatWithdrawalRequest{Currency: "USD", Amount: models.MustDecimal("1000"), BankAccountID: 3})
+	if err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	if _, err := client.CreateFiatWithdrawal(ctx, &FiatWithdrawalRequest{Currency: "USD", Amount: models.MustDecimal("1000"), BankAccountID: 6}); err == nil {
+		t.Errorf("Wrong error for a bank account in another currency")
+	}
+	if _, err := client.CreateFiatWithdrawal(ctx, &FiatWithdrawalRequest{Currency: "USD", Amount: models.MustDecimal("9500"), BankAccountID: 4}); !IsInsufficientBalance(err) {
+		t.Errorf("Wrong error for an overdraft. %+v", err)
+	}
+	if _, err := client.CreateFiatWithdrawal(ctx, &FiatWithdrawalRequest{Currency: "USD", Amount: models.MustDecimal("500"), BankAccountID: 4}); err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	cancelled, err := client.CancelFiatWithdrawal(ctx, first.ID)
+	if err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	if cancelled.State != models.WithdrawalStateCancelled {
+		t.Errorf("Wrong cancelled withdrawal. %+v", cancelled)
+	}
+	if _, err := client.CancelFiatWithdrawal(ctx, first.ID); err == nil {
+		t.Errorf("Wrong error cancelling a cancelled withdrawal")
+	}
+
+	pending, err := client.GetFiatWithdrawals(ctx, FiatWithdrawalFilter{Currency: "USD", State: models.WithdrawalStatePending})
+	if err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	if len(pending.Models) != 1 || pending.Models[0].BankAccountID != 4 {
+		t.Errorf("Wrong pending withdrawals. %+v", pending)
+	}
+	accounts, err := client.GetFiatAccounts(ctx)
+	if err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	if !accounts[0].Balance.Equal(models.MustDecimal("9500")) {
+		t.Errorf("Wrong balance. %s", accounts[0].Balance)
+	}
+	if _, err := client.GetFiatDeposits(ctx, FiatDepositFilter{State: "unknown"}); err == nil {
+		t.Errorf("Wrong error for an unknown state")
+	}
+}
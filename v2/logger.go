@@ -0,0 +1,139 @@
+package quoinex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+)
+
+type LogLevel int
+
+const (
+	LogLevelDebug LogLevel = iota
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LogLevelDebug:
+		return "DEBUG"
+	case LogLevelInfo:
+		return "INFO"
+	case LogLevelWarn:
+		return "WARN"
+	case LogLevelError:
+		return "ERROR"
+	}
+	return fmt.Sprintf("LEVEL(%d)", int(l))
+}
+
+type LogField struct {
+	Key   string
+	Value interface{}
+}
+
+// Logger is a leveled, structured logger. Request and response bodies are
+// only built and passed to Log when Enabled(LogLevelDebug) is true.
+type Logger interface {
+	Enabled(level LogLevel) bool
+	Log(ctx context.Context, level LogLevel, msg string, fields ...LogField)
+}
+
+const redacted = "[REDACTED]"
+
+var redactedHeaders = []string{"X-Quoine-Auth", "Authorization", "Cookie", "Set-Cookie"}
+
+var defaultRedactFields = []string{"balance", "equity", "free_margin", "address",
+	"account_number", "holder_name", "bank_name", "branch_name", "swift_code"}
+
+type nopLogger struct{}
+
+func (nopLogger) Enabled(LogLevel) bool                              { return false }
+func (nopLogger) Log(context.Context, LogLevel, string, ...LogField) {}
+
+type stdLogger struct {
+	l     *log.Logger
+	level LogLevel
+}
+
+// NewStdLogger adapts a *log.Logger, dropping entries below level.
+func NewStdLogger(l *log.Logger, level LogLevel) Logger {
+	return &stdLogger{l: l, level: level}
+}
+
+func (s *stdLogger) Enabled(level LogLevel) bool {
+	return level >= s.level
+}
+
+func (s *stdLogger) Log(ctx context.Context, level LogLevel, msg string, fields ...LogField) {
+	if !s.Enabled(level) {
+		return
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s] %s", level, msg)
+	for _, f := range fields {
+		v := fmt.Sprint(f.Value)
+		if strings.ContainsAny(v, " \t\n\"=") {
+			v = fmt.Sprintf("%q", v)
+		}
+		fmt.Fprintf(&b, " %s=%s", f.Key, v)
+	}
+	s.l.Print(b.String())
+}
+
+func redactHeader(h http.Header) http.Header {
+	out := make(http.Header, len(h))
+	for k, v := range h {
+		out[k] = v
+	}
+	for _, k := range redactedHeaders {
+		if out.Get(k) != "" {
+			out.Set(k, redacted)
+		}
+	}
+	return out
+}
+
+// redactBody masks the values of the given keys anywhere in a JSON document.
+// Bodies that are not JSON are returned unchanged.
+func redactBody(body []byte, fields []string) string {
+	if len(body) == 0 || len(fields) == 0 {
+		return string(body)
+	}
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return string(body)
+	}
+	keys := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		keys[f] = true
+	}
+	b, err := json.Marshal(redactValue(v, keys))
+	if err != nil {
+		return string(body)
+	}
+	return string(b)
+}
+
+func redactValue(v interface{}, keys map[string]bool) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, e := range t {
+			if keys[k] {
+				t[k] = redacted
+			} else {
+				t[k] = redactValue(e, keys)
+			}
+		}
+	case []interface{}:
+		for i, e := range t {
+			t[i] = redactValue(e, keys)
+		}
+	}
+	return v
+}
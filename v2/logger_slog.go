@@ -0,0 +1,42 @@
+//go:build go1.21
+// +build go1.21
+
+package quoinex
+
+import (
+	"context"
+	"log/slog"
+)
+
+type slogLogger struct {
+	l *slog.Logger
+}
+
+// NewSlogLogger adapts a *slog.Logger; its handler decides which levels are enabled.
+func NewSlogLogger(l *slog.Logger) Logger {
+	return &slogLogger{l: l}
+}
+
+func (s *slogLogger) Enabled(level LogLevel) bool {
+	return s.l.Enabled(context.Background(), slogLevel(level))
+}
+
+func (s *slogLogger) Log(ctx context.Context, level LogLevel, msg string, fields ...LogField) {
+	attrs := make([]slog.Attr, 0, len(fields))
+	for _, f := range fields {
+		attrs = append(attrs, slog.Any(f.Key, f.Value))
+	}
+	s.l.LogAttrs(ctx, slogLevel(level), msg, attrs...)
+}
+
+func slogLevel(level LogLevel) slog.Level {
+	switch level {
+	case LogLevelDebug:
+		return slog.LevelDebug
+	case LogLevelWarn:
+		return slog.LevelWarn
+	case LogLevelError:
+		return slog.LevelError
+	}
+	return slog.LevelInfo
+}
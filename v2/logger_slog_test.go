@@ -0,0 +1,36 @@
+//go:build go1.21
+// +build go1.21
+
+package quoinex
+
+import (
+	"bytes"
+	"context"
+	"github.com/sho3imo/quoinex-go-client/v2/testutil"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSlogLogger(t *testing.T) {
+	ts := testutil.GenerateTestServer(t, "/products/1", "GET", "", testutil.GetProductJsonResponse())
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL), WithLogger(NewSlogLogger(logger)))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := client.GetProduct(ctx, 1); err != nil {
+		t.Errorf("Error. %+v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "level=INFO msg=response operation=GetProduct method=GET path=/products/1 status=200") {
+		t.Errorf("Wrong log. %s", out)
+	}
+	if strings.Contains(out, "level=DEBUG") {
+		t.Errorf("Wrong log. debug entries must be dropped: %s", out)
+	}
+}
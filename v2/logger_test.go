@@ -0,0 +1,110 @@
+package quoinex
+
+import (
+	"bytes"
+	"context"
+	"github.com/sho3imo/quoinex-go-client/v2/testutil"
+	"log"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStdLogger(t *testing.T) {
+	type Param struct {
+		level    LogLevel
+		path     string
+		response string
+		logFunc  func(c *Client, ctx context.Context)
+	}
+	type Expect struct {
+		contains    []string
+		notContains []string
+	}
+	getAccounts := func(c *Client, ctx context.Context) {
+		if _, err := c.GetFiatAccounts(ctx); err != nil {
+			t.Errorf("Error. %+v", err)
+		}
+	}
+	getBankAccounts := func(c *Client, ctx context.Context) {
+		if _, err := c.GetBankAccounts(ctx, BankAccountFilter{}); err != nil {
+			t.Errorf("Error. %+v", err)
+		}
+	}
+	fiatAccounts := testutil.GetFiatAccountsJsonResponse()
+	bankAccounts := `{"models":[{"id":7,"currency":"USD","bank_name":"First Bank","branch_name":"Main","account_number":"0123456789","holder_name":"Jane Roe","swift_code":"FRSTUS33","country":"US"}],"current_page":1,"total_pages":1}`
+	cases := []struct {
+		param  Param
+		expect Expect
+	}{
+		// test case 1: info logs the summary only
+		{
+			param: Param{level: LogLevelInfo, path: "/fiat_accounts", response: fiatAccounts, logFunc: getAccounts},
+			expect: Expect{
+				contains:    []string{"[INFO] response operation=GetFiatAccounts method=GET path=/fiat_accounts status=200 latency="},
+				notContains: []string{"DEBUG", "X-Quoine-Auth", "10000.1773"},
+			},
+		},
+		// test case 2: debug dumps redacted headers and bodies
+		{
+			param: Param{level: LogLevelDebug, path: "/fiat_accounts", response: fiatAccounts, logFunc: getAccounts},
+			expect: Expect{
+				contains:    []string{"[DEBUG] request operation=GetFiatAccounts method=GET", "X-Quoine-Auth:[[REDACTED]]", `\"balance\":\"[REDACTED]\"`, `\"currency\":\"USD\"`},
+				notContains: []string{"10000.1773", "eyJ"},
+			},
+		},
+		// test case 3: errors above the threshold only
+		{
+			param:  Param{level: LogLevelError, path: "/fiat_accounts", response: fiatAccounts, logFunc: getAccounts},
+			expect: Expect{notContains: []string{"INFO", "DEBUG"}},
+		},
+		// test case 4: bank account details are masked
+		{
+			param: Param{level: LogLevelDebug, path: "/bank_accounts", response: bankAccounts, logFunc: getBankAccounts},
+			expect: Expect{
+				contains:    []string{`\"account_number\":\"[REDACTED]\"`, `\"holder_name\":\"[REDACTED]\"`, `\"country\":\"US\"`},
+				notContains: []string{"0123456789", "Jane Roe", "First Bank", "Main", "FRSTUS33"},
+			},
+		},
+	}
+	for _, c := range cases {
+		ts := testutil.GenerateTestServer(t, c.param.path, "GET", "", c.param.response)
+		defer ts.Close()
+
+		var buf bytes.Buffer
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL), WithLogger(NewStdLogger(log.New(&buf, "", 0), c.param.level)))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		c.param.logFunc(client, ctx)
+
+		out := buf.String()
+		for _, s := range c.expect.contains {
+			if !strings.Contains(out, s) {
+				t.Errorf("Wrong log. missing %q in:\n%s", s, out)
+			}
+		}
+		for _, s := range c.expect.notContains {
+			if strings.Contains(out, s) {
+				t.Errorf("Wrong log. unexpected %q in:\n%s", s, out)
+			}
+		}
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	cases := []struct {
+		body   string
+		fields []string
+		expect string
+	}{
+		{body: `{"order":{"price":"500.0","quantity":"1"}}`, fields: []string{"price"}, expect: `{"order":{"price":"[REDACTED]","quantity":"1"}}`},
+		{body: `[{"balance":"1.0"},{"balance":2}]`, fields: []string{"balance"}, expect: `[{"balance":"[REDACTED]"},{"balance":"[REDACTED]"}]`},
+		{body: `not json`, fields: []string{"balance"}, expect: `not json`},
+		{body: ``, fields: []string{"balance"}, expect: ``},
+	}
+	for _, c := range cases {
+		if actual := redactBody([]byte(c.body), c.fields); actual != c.expect {
+			t.Errorf("Wrong body. actual: %s, expect: %s", actual, c.expect)
+		}
+	}
+}
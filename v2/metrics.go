@@ -0,0 +1,74 @@
+package quoinex
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+)
+
+// Error classes reported in RequestMetric.ErrorClass.
+const (
+	ErrorClassNone        = ""
+	ErrorClassClient      = "client_error"
+	ErrorClassRateLimited = "rate_limited"
+	ErrorClassServer      = "server_error"
+	ErrorClassNetwork     = "network"
+	ErrorClassTimeout     = "timeout"
+	ErrorClassCanceled    = "canceled"
+	ErrorClassOther       = "other"
+)
+
+// RequestMetric describes one HTTP attempt.
+type RequestMetric struct {
+	Operation  string
+	Method     string
+	Attempt    int
+	StatusCode int // 0 when no response was received
+	ErrorClass string
+	Latency    time.Duration
+}
+
+// Metrics receives measurements from the request pipeline. Implementations
+// must be safe for concurrent use.
+type Metrics interface {
+	// ObserveRequest is called once per HTTP attempt, retries included.
+	ObserveRequest(ctx context.Context, m RequestMetric)
+	// ObserveRateLimitWait is called for every request that went through
+	// the client's rate limiter, with the time it was held back.
+	ObserveRateLimitWait(ctx context.Context, operation string, wait time.Duration)
+}
+
+type nopMetrics struct{}
+
+func (nopMetrics) ObserveRequest(context.Context, RequestMetric)               {}
+func (nopMetrics) ObserveRateLimitWait(context.Context, string, time.Duration) {}
+
+func errorClass(err error) string {
+	if err == nil {
+		return ErrorClassNone
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.IsRateLimited():
+			return ErrorClassRateLimited
+		case apiErr.StatusCode >= 500:
+			return ErrorClassServer
+		default:
+			return ErrorClassClient
+		}
+	}
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.Canceled):
+		return ErrorClassCanceled
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, RateLimitDeadlineError):
+		return ErrorClassTimeout
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return ErrorClassTimeout
+	case errors.As(err, &netErr):
+		return ErrorClassNetwork
+	}
+	return ErrorClassOther
+}
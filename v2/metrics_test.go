@@ -0,0 +1,86 @@
+package quoinex
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingMetrics struct {
+	mu       sync.Mutex
+	requests []RequestMetric
+	waits    []string
+}
+
+func (m *recordingMetrics) ObserveRequest(ctx context.Context, r RequestMetric) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests = append(m.requests, r)
+}
+
+func (m *recordingMetrics) ObserveRateLimitWait(ctx context.Context, operation string, wait time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.waits = append(m.waits, operation)
+}
+
+func TestErrorClass(t *testing.T) {
+	type Param struct {
+		err error
+	}
+	type Expect struct {
+		class string
+	}
+	cases := []struct {
+		param  Param
+		expect Expect
+	}{
+		// test case 1
+		{param: Param{err: nil}, expect: Expect{class: ErrorClassNone}},
+		// test case 2
+		{param: Param{err: &APIError{StatusCode: 429}}, expect: Expect{class: ErrorClassRateLimited}},
+		// test case 3
+		{param: Param{err: &APIError{StatusCode: 502}}, expect: Expect{class: ErrorClassServer}},
+		// test case 4
+		{param: Param{err: &APIError{StatusCode: 422}}, expect: Expect{class: ErrorClassClient}},
+		// test case 5
+		{param: Param{err: &url.Error{Op: "Get", URL: "http://x", Err: context.Canceled}}, expect: Expect{class: ErrorClassCanceled}},
+		// test case 6
+		{param: Param{err: RateLimitDeadlineError}, expect: Expect{class: ErrorClassTimeout}},
+		// test case 7
+		{param: Param{err: &url.Error{Op: "Get", URL: "http://x", Err: errConnRefused{}}}, expect: Expect{class: ErrorClassNetwork}},
+	}
+	for _, c := range cases {
+		if got := errorClass(c.param.err); got != c.expect.class {
+			t.Errorf("Wrong class for %v. actual: %q, expect: %q", c.param.err, got, c.expect.class)
+		}
+	}
+}
+
+type errConnRefused struct{}
+
+func (errConnRefused) Error() string { return "connection refused" }
+
+func TestMetricsPerAttempt(t *testing.T) {
+	ts, _ := sequenceServer(t, cannedResponse{statusCode: 500, body: `{}`}, cannedResponse{statusCode: 200, body: `{"id":"1"}`})
+	defer ts.Close()
+
+	metrics := &recordingMetrics{}
+	client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL), WithMetrics(metrics),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}))
+	if _, err := client.GetProduct(context.Background(), 1); err != nil {
+		t.Errorf("Error. %+v", err)
+	}
+	if len(metrics.requests) != 2 || len(metrics.waits) != 2 {
+		t.Fatalf("Wrong calls. requests: %d, waits: %d", len(metrics.requests), len(metrics.waits))
+	}
+	first, second := metrics.requests[0], metrics.requests[1]
+	if first.Attempt != 1 || first.StatusCode != 500 || first.ErrorClass != ErrorClassServer {
+		t.Errorf("Wrong first attempt. %+v", first)
+	}
+	if second.Attempt != 2 || second.StatusCode != 200 || second.ErrorClass != ErrorClassNone || second.Operation != "GetProduct" {
+		t.Errorf("Wrong second attempt. %+v", second)
+	}
+}
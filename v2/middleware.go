@@ -0,0 +1,44 @@
+package quoinex
+
+import (
+	"context"
+	"net/http"
+)
+
+// Request is one signed attempt of a logical API operation.
+type Request struct {
+	// Operation is the Client method name, e.g. "CreateAnOrder".
+	Operation string
+	Method    string
+	// Path is the API path without the query string, e.g. "/orders/1".
+	Path    string
+	Attempt int
+	// Nonce is the nonce signed into HTTPRequest.
+	Nonce int64
+	// Body is the JSON payload, nil for requests without a body.
+	Body        []byte
+	HTTPRequest *http.Request
+	// Out is where the response body is decoded; nil discards it.
+	Out interface{}
+}
+
+// Response is the outcome of a successful round trip. The HTTP body has
+// already been read into Body and decoded into Result.
+type Response struct {
+	HTTPResponse *http.Response
+	Body         []byte
+	Result       interface{}
+}
+
+type RoundTripFunc func(ctx context.Context, req *Request) (*Response, error)
+
+// Middleware wraps every request the Client sends, including retries.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// chain applies middlewares so that the first one is the outermost.
+func chain(rt RoundTripFunc, middlewares []Middleware) RoundTripFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		rt = middlewares[i](rt)
+	}
+	return rt
+}
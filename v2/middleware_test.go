@@ -0,0 +1,131 @@
+package quoinex
+
+import (
+	"context"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sho3imo/quoinex-go-client/v2/testutil"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMiddlewareSeesOperation(t *testing.T) {
+	type Param struct {
+		path         string
+		jsonResponse string
+		call         func(c *Client, ctx context.Context) error
+	}
+	type Expect struct {
+		operation string
+		result    interface{}
+	}
+	cases := []struct {
+		param  Param
+		expect Expect
+	}{
+		// test case 1
+		{
+			param: Param{path: "/executions?limit=1&page=1&product_id=1", jsonResponse: testutil.GetExecutionsJsonResponse(), call: func(c *Client, ctx context.Context) error {
+				_, err := c.GetExecutions(ctx, 1, 1, 1)
+				return err
+			}},
+			expect: Expect{operation: "GetExecutions", result: testutil.GetExpectedExecutionsModel()},
+		},
+		// test case 2
+		{
+			param: Param{path: "/executions/me?product_id=1001232", jsonResponse: testutil.GetOwnExecutionsJsonResponse(), call: func(c *Client, ctx context.Context) error {
+				_, err := c.GetOwnExecutions(ctx, 1001232)
+				return err
+			}},
+			expect: Expect{operation: "GetOwnExecutions", result: testutil.GetExpectedOwnExecutionsModel()},
+		},
+		// test case 3
+		{
+			param: Param{path: "/orders/1", jsonResponse: testutil.GetOrderJsonResponse(), call: func(c *Client, ctx context.Context) error {
+				_, err := c.GetAnOrder(ctx, 1)
+				return err
+			}},
+			expect: Expect{operation: "GetAnOrder", result: testutil.GetExpectedOrderModel()},
+		},
+	}
+	for _, c := range cases {
+		ts := testutil.GenerateTestServer(t, c.param.path, "GET", "", c.param.jsonResponse)
+		defer ts.Close()
+
+		var seen []*Request
+		var results []interface{}
+		recorder := func(next RoundTripFunc) RoundTripFunc {
+			return func(ctx context.Context, req *Request) (*Response, error) {
+				seen = append(seen, req)
+				res, err := next(ctx, req)
+				if err == nil {
+					results = append(results, res.Result)
+				}
+				return res, err
+			}
+		}
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL), WithMiddleware(recorder))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := c.param.call(client, ctx); err != nil {
+			t.Errorf("Error. %+v", err)
+		}
+		if len(seen) != 1 || len(results) != 1 {
+			t.Fatalf("Wrong calls. requests: %d, results: %d", len(seen), len(results))
+		}
+		if seen[0].Operation != c.expect.operation {
+			t.Errorf("Wrong operation. actual: %s, expect: %s", seen[0].Operation, c.expect.operation)
+		}
+		if seen[0].HTTPRequest == nil || seen[0].HTTPRequest.Header.Get("X-Quoine-Auth") == "" {
+			t.Errorf("Wrong request. middleware must see the signed request")
+		}
+		// Result points at the value the method decodes into
+		result := reflect.ValueOf(results[0]).Elem().Interface()
+		expect := reflect.ValueOf(c.expect.result).Elem().Interface()
+		if !cmp.Equal(result, expect) {
+			t.Errorf("Wrong result. %+v", cmp.Diff(result, expect))
+		}
+	}
+}
+
+func TestMiddlewareOrderAndFaultInjection(t *testing.T) {
+	ts := testutil.GenerateTestServer(t, "/products/1", "GET", "", testutil.GetProductJsonResponse())
+	defer ts.Close()
+
+	var trace []string
+	named := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(ctx context.Context, req *Request) (*Response, error) {
+				trace = append(trace, name)
+				return next(ctx, req)
+			}
+		}
+	}
+	// fail the first attempt as if the exchange were unavailable
+	faulty := func(next RoundTripFunc) RoundTripFunc {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			if req.Attempt == 1 {
+				return nil, &APIError{StatusCode: http.StatusServiceUnavailable, Method: req.Method, Path: req.Path}
+			}
+			return next(ctx, req)
+		}
+	}
+	client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}),
+		WithMiddleware(named("outer"), named("inner")), WithMiddleware(faulty))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	product, err := client.GetProduct(ctx, 1)
+	if err != nil {
+		t.Errorf("Error. %+v", err)
+	}
+	if !cmp.Equal(product, testutil.GetExpectedProductmodel()) {
+		t.Errorf("Wrong attribute. %+v", cmp.Diff(product, testutil.GetExpectedProductmodel()))
+	}
+	expect := []string{"outer", "inner", "outer", "inner"}
+	if !cmp.Equal(trace, expect) {
+		t.Errorf("Wrong order. %+v", cmp.Diff(trace, expect))
+	}
+}
@@ -0,0 +1,552 @@
+// Package mock provides a scriptable quoinex.API for unit tests that should
+// not touch the network.
+//
+//	m := mock.New()
+//	m.On("GetProduct").Return(&models.Product{ID: "5"}, nil)
+//	m.On("CancelAnOrder").Return(nil, errors.New("boom"))
+//	strategy := NewStrategy(m) // takes a quoinex.API
+//	...
+//	if m.CallCount("CancelAnOrder") != 1 {
+//		t.Errorf(...)
+//	}
+package mock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/sho3imo/quoinex-go-client/v2"
+	"github.com/sho3imo/quoinex-go-client/v2/models"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// ErrNotScripted is returned, wrapped, by a method that has no response.
+var ErrNotScripted = errors.New("mock: no response scripted")
+
+// Call is one recorded method call. Args holds the arguments after the
+// context, in order.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// Stub is a scripted response of one method.
+type Stub struct {
+	values []interface{}
+	times  int
+	used   int
+}
+
+// Return sets the results of the method, in order and including the
+// error. A nil value leaves that result at its zero value.
+func (s *Stub) Return(values ...interface{}) *Stub {
+	s.values = values
+	return s
+}
+
+// Times sets how many calls the stub answers before the next stub of the
+// same method takes over; the default is one.
+func (s *Stub) Times(n int) *Stub {
+	s.times = n
+	return s
+}
+
+// Client implements quoinex.API. Each call is recorded and answered by the
+// stubs of its method in the order they were added; the last stub answers
+// every call after the others are used up. Iterator methods consume one
+// stub per page: Orders expects (*models.Orders, error) and
+// ExecutionHistory ([]*models.ExecutionsModels, error) per batch.
+type Client struct {
+	mu    sync.Mutex
+	calls []Call
+	stubs map[string][]*Stub
+}
+
+var _ quoinex.API = (*Client)(nil)
+
+func New() *Client {
+	return &Client{stubs: map[string][]*Stub{}}
+}
+
+// On adds a stub for method, e.g. On("GetAnOrder").
+func (m *Client) On(method string) *Stub {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := &Stub{times: 1}
+	m.stubs[method] = append(m.stubs[method], s)
+	return s
+}
+
+// Calls returns every recorded call in order.
+func (m *Client) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Call(nil), m.calls...)
+}
+
+// CallCount returns how many times method was called.
+func (m *Client) CallCount(method string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := 0
+	for _, c := range m.calls {
+		if c.Method == method {
+			n++
+		}
+	}
+	return n
+}
+
+// Reset forgets the recorded calls and the stubs.
+func (m *Client) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+	m.stubs = map[string][]*Stub{}
+}
+
+func (m *Client) record(method string, args ...interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+}
+
+// answer stores the values of the current stub of method into outs, which
+// point to the method's results. It panics when the stub does not fit.
+func (m *Client) answer(method string, outs ...interface{}) {
+	m.mu.Lock()
+	stubs := m.stubs[method]
+	var stub *Stub
+	for _, s := range stubs {
+		if s.used < s.times {
+			stub = s
+			break
+		}
+	}
+	if stub == nil && len(stubs) > 0 {
+		stub = stubs[len(stubs)-1]
+	}
+	if stub != nil {
+		stub.used++
+	}
+	m.mu.Unlock()
+
+	if stub == nil {
+		if errp, ok := outs[len(outs)-1].(*error); ok {
+			*errp = fmt.Errorf("%w: %s", ErrNotScripted, method)
+		}
+		return
+	}
+	if len(stub.values) != len(outs) {
+		panic(fmt.Sprintf("mock: %s returns %d values, stub has %d", method, len(outs), len(stub.values)))
+	}
+	for i, v := range stub.values {
+		if v == nil {
+			continue
+		}
+		out := reflect.ValueOf(outs[i]).Elem()
+		val := reflect.ValueOf(v)
+		if !val.Type().AssignableTo(out.Type()) {
+			panic(fmt.Sprintf("mock: %s result %d is %s, stub has %T", method, i, out.Type(), v))
+		}
+		out.Set(val)
+	}
+}
+
+func (m *Client) GetFiatAccounts(ctx context.Context) (accounts []*models.Account, err error) {
+	m.record("GetFiatAccounts")
+	m.answer("GetFiatAccounts", &accounts, &err)
+	return
+}
+
+func (m *Client) CreateAFiatAccount(ctx context.Context, currency string) (account *models.Account, err error) {
+	m.record("CreateAFiatAccount", currency)
+	m.answer("CreateAFiatAccount", &account, &err)
+	return
+}
+
+func (m *Client) CreateFiatAccount(ctx context.Context, req *quoinex.FiatAccountRequest) (account *models.Account, err error) {
+	m.record("CreateFiatAccount", req)
+	m.answer("CreateFiatAccount", &account, &err)
+	return
+}
+
+func (m *Client) GetCryptoAccounts(ctx context.Context) (accounts []*models.CryptoAccount, err error) {
+	m.record("GetCryptoAccounts")
+	m.answer("GetCryptoAccounts", &accounts, &err)
+	return
+}
+
+func (m *Client) GetAllAccountBalances(ctx context.Context) (balances []*models.AccountBalance, err error) {
+	m.record("GetAllAccountBalances")
+	m.answer("GetAllAccountBalances", &balances, &err)
+	return
+}
+
+func (m *Client) GetFiatDeposits(ctx context.Context, filter quoinex.FiatDepositFilter) (deposits *models.FiatDeposits, err error) {
+	m.record("GetFiatDeposits", filter)
+	m.answer("GetFiatDeposits", &deposits, &err)
+	return
+}
+
+func (m *Client) FiatDeposits(ctx context.Context, filter quoinex.FiatDepositFilter) *quoinex.FiatDepositIterator {
+	m.record("FiatDeposits", filter)
+	return quoinex.NewFiatDepositIterator(ctx, filter.ListOptions, func(ctx context.Context, page int) (deposits *models.FiatDeposits, err error) {
+		m.answer("FiatDeposits", &deposits, &err)
+		return
+	})
+}
+
+func (m *Client) CreateFiatWithdrawal(ctx context.Context, req *quoinex.FiatWithdrawalRequest) (withdrawal *models.FiatWithdrawal, err error) {
+	m.record("CreateFiatWithdrawal", req)
+	m.answer("CreateFiatWithdrawal", &withdrawal, &err)
+	return
+}
+
+func (m *Client) GetFiatWithdrawals(ctx context.Context, filter quoinex.FiatWithdrawalFilter) (withdrawals *models.FiatWithdrawals, err error) {
+	m.record("GetFiatWithdrawals", filter)
+	m.answer("GetFiatWithdrawals", &withdrawals, &err)
+	return
+}
+
+func (m *Client) FiatWithdrawals(ctx context.Context, filter quoinex.FiatWithdrawalFilter) *quoinex.FiatWithdrawalIterator {
+	m.record("FiatWithdrawals", filter)
+	return quoinex.NewFiatWithdrawalIterator(ctx, filter.ListOptions, func(ctx context.Context, page int) (withdrawals *models.FiatWithdrawals, err error) {
+		m.answer("FiatWithdrawals", &withdrawals, &err)
+		return
+	})
+}
+
+func (m *Client) CancelFiatWithdrawal(ctx context.Context, withdrawalID int) (withdrawal *models.FiatWithdrawal, err error) {
+	m.record("CancelFiatWithdrawal", withdrawalID)
+	m.answer("CancelFiatWithdrawal", &withdrawal, &err)
+	return
+}
+
+func (m *Client) GetBankAccounts(ctx context.Context, filter quoinex.BankAccountFilter) (accounts *models.BankAccounts, err error) {
+	m.record("GetBankAccounts", filter)
+	m.answer("GetBankAccounts", &accounts, &err)
+	return
+}
+
+func (m *Client) BankAccounts(ctx context.Context, filter quoinex.BankAccountFilter) *quoinex.BankAccountIterator {
+	m.record("BankAccounts", filter)
+	return quoinex.NewBankAccountIterator(ctx, filter.ListOptions, func(ctx context.Context, page int) (accounts *models.BankAccounts, err error) {
+		m.answer("BankAccounts", &accounts, &err)
+		return
+	})
+}
+
+func (m *Client) CreateCryptoWithdrawal(ctx context.Context, req *quoinex.CryptoWithdrawalRequest) (withdrawal *models.CryptoWithdrawal, err error) {
+	m.record("CreateCryptoWithdrawal", req)
+	m.answer("CreateCryptoWithdrawal", &withdrawal, &err)
+	return
+}
+
+func (m *Client) GetCryptoWithdrawals(ctx context.Context, filter quoinex.CryptoWithdrawalFilter) (withdrawals *models.CryptoWithdrawals, err error) {
+	m.record("GetCryptoWithdrawals", filter)
+	m.answer("GetCryptoWithdrawals", &withdrawals, &err)
+	return
+}
+
+func (m *Client) CryptoWithdrawals(ctx context.Context, filter quoinex.CryptoWithdrawalFilter) *quoinex.CryptoWithdrawalIterator {
+	m.record("CryptoWithdrawals", filter)
+	return quoinex.NewCryptoWithdrawalIterator(ctx, filter.ListOptions, func(ctx context.Context, page int) (withdrawals *models.CryptoWithdrawals, err error) {
+		m.answer("CryptoWithdrawals", &withdrawals, &err)
+		return
+	})
+}
+
+func (m *Client) CancelCryptoWithdrawal(ctx context.Context, withdrawalID int) (withdrawal *models.CryptoWithdrawal, err error) {
+	m.record("CancelCryptoWithdrawal", withdrawalID)
+	m.answer("CancelCryptoWithdrawal", &withdrawal, &err)
+	return
+}
+
+func (m *Client) GetWithdrawalAddresses(ctx context.Context, currency string) (addresses []*models.WithdrawalAddress, err error) {
+	m.record("GetWithdrawalAddresses", currency)
+	m.answer("GetWithdrawalAddresses", &addresses, &err)
+	return
+}
+
+func (m *Client) CreateALoanBid(ctx context.Context, quantity models.Decimal, currency string, rate models.Decimal) (loanBid *models.LoanBid, err error) {
+	m.record("CreateALoanBid", quantity, currency, rate)
+	m.answer("CreateALoanBid", &loanBid, &err)
+	return
+}
+
+func (m *Client) CreateLoanBid(ctx context.Context, req *quoinex.LoanBidRequest) (loanBid *models.LoanBid, err error) {
+	m.record("CreateLoanBid", req)
+	m.answer("CreateLoanBid", &loanBid, &err)
+	return
+}
+
+func (m *Client) GetLoanBids(ctx context.Context, currency string) (loanBids *models.LoanBids, err error) {
+	m.record("GetLoanBids", currency)
+	m.answer("GetLoanBids", &loanBids, &err)
+	return
+}
+
+func (m *Client) LoanBids(ctx context.Context, filter quoinex.LoanBidFilter) *quoinex.LoanBidIterator {
+	m.record("LoanBids", filter)
+	return quoinex.NewLoanBidIterator(ctx, filter.ListOptions, func(ctx context.Context, page int) (loanBids *models.LoanBids, err error) {
+		m.answer("LoanBids", &loanBids, &err)
+		return
+	})
+}
+
+func (m *Client) CloseLoanBid(ctx context.Context, loanBidID int) (loanBid *models.LoanBid, err error) {
+	m.record("CloseLoanBid", loanBidID)
+	m.answer("CloseLoanBid", &loanBid, &err)
+	return
+}
+
+func (m *Client) GetLoans(ctx context.Context, currency string) (loans *models.Loans, err error) {
+	m.record("GetLoans", currency)
+	m.answer("GetLoans", &loans, &err)
+	return
+}
+
+func (m *Client) Loans(ctx context.Context, filter quoinex.LoanFilter) *quoinex.LoanIterator {
+	m.record("Loans", filter)
+	return quoinex.NewLoanIterator(ctx, filter.ListOptions, func(ctx context.Context, page int) (loans *models.Loans, err error) {
+		m.answer("Loans", &loans, &err)
+		return
+	})
+}
+
+func (m *Client) UpdateALoan(ctx context.Context, loanID int, fundReloaned bool) (loan *models.Loan, err error) {
+	m.record("UpdateALoan", loanID, fundReloaned)
+	m.answer("UpdateALoan", &loan, &err)
+	return
+}
+
+func (m *Client) GetInterestRates(ctx context.Context, currency string) (rates *models.InterestRates, err error) {
+	m.record("GetInterestRates", currency)
+	m.answer("GetInterestRates", &rates, &err)
+	return
+}
+
+func (m *Client) GetOrderBook(ctx context.Context, productID int, full bool) (priceLevels *models.PriceLevels, err error) {
+	m.record("GetOrderBook", productID, full)
+	m.answer("GetOrderBook", &priceLevels, &err)
+	return
+}
+
+func (m *Client) GetProducts(ctx context.Context) (products []*models.Product, err error) {
+	m.record("GetProducts")
+	m.answer("GetProducts", &products, &err)
+	return
+}
+
+func (m *Client) GetProduct(ctx context.Context, productID int) (product *models.Product, err error) {
+	m.record("GetProduct", productID)
+	m.answer("GetProduct", &product, &err)
+	return
+}
+
+func (m *Client) GetExecutionsByTimestamp(ctx context.Context, productID int, limit int, timestamp time.Time) (executions []*models.ExecutionsModels, err error) {
+	m.record("GetExecutionsByTimestamp", productID, limit, timestamp)
+	m.answer("GetExecutionsByTimestamp", &executions, &err)
+	return
+}
+
+func (m *Client) GetExecutions(ctx context.Context, productID int, limit int, page int) (executions *models.Executions, err error) {
+	m.record("GetExecutions", productID, limit, page)
+	m.answer("GetExecutions", &executions, &err)
+	return
+}
+
+func (m *Client) GetOwnExecutions(ctx context.Context, productID int) (executions *models.Executions, err error) {
+	m.record("GetOwnExecutions", productID)
+	m.answer("GetOwnExecutions", &executions, &err)
+	return
+}
+
+func (m *Client) Executions(ctx context.Context, filter quoinex.ExecutionFilter) *quoinex.ExecutionIterator {
+	m.record("Executions", filter)
+	return quoinex.NewExecutionIterator(ctx, filter.ListOptions, func(ctx context.Context, page int) (executions *models.Executions, err error) {
+		m.answer("Executions", &executions, &err)
+		return
+	})
+}
+
+func (m *Client) OwnExecutions(ctx context.Context, filter quoinex.ExecutionFilter) *quoinex.ExecutionIterator {
+	m.record("OwnExecutions", filter)
+	return quoinex.NewExecutionIterator(ctx, filter.ListOptions, func(ctx context.Context, page int) (executions *models.Executions, err error) {
+		m.answer("OwnExecutions", &executions, &err)
+		return
+	})
+}
+
+func (m *Client) ExecutionHistory(ctx context.Context, productID int, from, to time.Time, opts ...quoinex.HistoryOption) *quoinex.ExecutionHistoryIterator {
+	m.record("ExecutionHistory", productID, from, to)
+	fetch := func(ctx context.Context, productID int, limit int, timestamp time.Time) (executions []*models.ExecutionsModels, err error) {
+		m.answer("ExecutionHistory", &executions, &err)
+		return
+	}
+	return quoinex.NewExecutionHistoryIterator(ctx, fetch, productID, from, to, opts...)
+}
+
+func (m *Client) GetAnOrder(ctx context.Context, orderID int) (order *models.Order, err error) {
+	m.record("GetAnOrder", orderID)
+	m.answer("GetAnOrder", &order, &err)
+	return
+}
+
+func (m *Client) GetOrders(ctx context.Context, productID, withDetails int, fundingCurrency string, status models.OrderStatus) (orders *models.Orders, err error) {
+	m.record("GetOrders", productID, withDetails, fundingCurrency, status)
+	m.answer("GetOrders", &orders, &err)
+	return
+}
+
+func (m *Client) GetOrdersByFilter(ctx context.Context, filter quoinex.OrderFilter) (orders *models.Orders, err error) {
+	m.record("GetOrdersByFilter", filter)
+	m.answer("GetOrdersByFilter", &orders, &err)
+	return
+}
+
+func (m *Client) Orders(ctx context.Context, filter quoinex.OrderFilter) *quoinex.OrderIterator {
+	m.record("Orders", filter)
+	return quoinex.NewOrderIterator(ctx, filter.ListOptions, func(ctx context.Context, page int) (orders *models.Orders, err error) {
+		m.answer("Orders", &orders, &err)
+		return
+	})
+}
+
+func (m *Client) CreateAnOrder(ctx context.Context, orderType models.OrderType, side models.Side, quantity, price, priceRange models.Decimal, productID int, clientOrderID string) (order *models.Order, err error) {
+	m.record("CreateAnOrder", orderType, side, quantity, price, priceRange, productID, clientOrderID)
+	m.answer("CreateAnOrder", &order, &err)
+	return
+}
+
+func (m *Client) CreateOrder(ctx context.Context, req *quoinex.CreateOrderRequest) (order *models.Order, err error) {
+	m.record("CreateOrder", req)
+	m.answer("CreateOrder", &order, &err)
+	return
+}
+
+func (m *Client) CancelAnOrder(ctx context.Context, orderID int) (order *models.Order, err error) {
+	m.record("CancelAnOrder", orderID)
+	m.answer("CancelAnOrder", &order, &err)
+	return
+}
+
+func (m *Client) EditALiveOrder(ctx context.Context, orderID int, quantity, price models.Decimal) (order *models.Order, err error) {
+	m.record("EditALiveOrder", orderID, quantity, price)
+	m.answer("EditALiveOrder", &order, &err)
+	return
+}
+
+func (m *Client) EditOrder(ctx context.Context, orderID int, req *quoinex.EditOrderRequest) (order *models.Order, err error) {
+	m.record("EditOrder", orderID, req)
+	m.answer("EditOrder", &order, &err)
+	return
+}
+
+func (m *Client) GetOrderByClientID(ctx context.Context, clientOrderID string) (order *models.Order, err error) {
+	m.record("GetOrderByClientID", clientOrderID)
+	m.answer("GetOrderByClientID", &order, &err)
+	return
+}
+
+func (m *Client) CancelOrderByClientID(ctx context.Context, clientOrderID string) (order *models.Order, err error) {
+	m.record("CancelOrderByClientID", clientOrderID)
+	m.answer("CancelOrderByClientID", &order, &err)
+	return
+}
+
+func (m *Client) EditOrderByClientID(ctx context.Context, clientOrderID string, req *quoinex.EditOrderRequest) (order *models.Order, err error) {
+	m.record("EditOrderByClientID", clientOrderID, req)
+	m.answer("EditOrderByClientID", &order, &err)
+	return
+}
+
+func (m *Client) CancelAllOrders(ctx context.Context, filter quoinex.OrderFilter, opts ...quoinex.CancelAllOption) (report *quoinex.CancelReport, err error) {
+	m.record("CancelAllOrders", filter)
+	m.answer("CancelAllOrders", &report, &err)
+	return
+}
+
+func (m *Client) GetAnOrderTrades(ctx context.Context, orderID int) (trades []*models.Trade, err error) {
+	m.record("GetAnOrderTrades", orderID)
+	m.answer("GetAnOrderTrades", &trades, &err)
+	return
+}
+
+func (m *Client) GetTrades(ctx context.Context, fundingCurrency string, status models.TradeStatus) (trades *models.Trades, err error) {
+	m.record("GetTrades", fundingCurrency, status)
+	m.answer("GetTrades", &trades, &err)
+	return
+}
+
+func (m *Client) Trades(ctx context.Context, filter quoinex.TradeFilter) *quoinex.TradeIterator {
+	m.record("Trades", filter)
+	return quoinex.NewTradeIterator(ctx, filter.ListOptions, func(ctx context.Context, page int) (trades *models.Trades, err error) {
+		m.answer("Trades", &trades, &err)
+		return
+	})
+}
+
+func (m *Client) CloseTrade(ctx context.Context, tradeID int, closedQuantity models.Decimal) (trade *models.Trade, err error) {
+	m.record("CloseTrade", tradeID, closedQuantity)
+	m.answer("CloseTrade", &trade, &err)
+	return
+}
+
+func (m *Client) CloseAllTrade(ctx context.Context, side models.TradeSide) (trades []*models.Trade, err error) {
+	m.record("CloseAllTrade", side)
+	m.answer("CloseAllTrade", &trades, &err)
+	return
+}
+
+func (m *Client) UpdateTrade(ctx context.Context, tradeID int, stopLoss, takeProfit models.Decimal) (trade *models.Trade, err error) {
+	m.record("UpdateTrade", tradeID, stopLoss, takeProfit)
+	m.answer("UpdateTrade", &trade, &err)
+	return
+}
+
+func (m *Client) EditTrade(ctx context.Context, tradeID int, req *quoinex.EditTradeRequest) (trade *models.Trade, err error) {
+	m.record("EditTrade", tradeID, req)
+	m.answer("EditTrade", &trade, &err)
+	return
+}
+
+func (m *Client) GetTradesLoans(ctx context.Context, tradeID int) (loans []*models.Loan, err error) {
+	m.record("GetTradesLoans", tradeID)
+	m.answer("GetTradesLoans", &loans, &err)
+	return
+}
+
+func (m *Client) GetTradingAccounts(ctx context.Context) (accounts []*models.TradingAccount, err error) {
+	m.record("GetTradingAccounts")
+	m.answer("GetTradingAccounts", &accounts, &err)
+	return
+}
+
+func (m *Client) GetATradingAccount(ctx context.Context, tradingAccountID int) (account *models.TradingAccount, err error) {
+	m.record("GetATradingAccount", tradingAccountID)
+	m.answer("GetATradingAccount", &account, &err)
+	return
+}
+
+func (m *Client) UpdateLeverageLevel(ctx context.Context, tradeAccountID, leverageLevel int) (account *models.TradingAccount, err error) {
+	m.record("UpdateLeverageLevel", tradeAccountID, leverageLevel)
+	m.answer("UpdateLeverageLevel", &account, &err)
+	return
+}
+
+func (m *Client) EditTradingAccount(ctx context.Context, tradeAccountID int, req *quoinex.EditTradingAccountRequest) (account *models.TradingAccount, err error) {
+	m.record("EditTradingAccount", tradeAccountID, req)
+	m.answer("EditTradingAccount", &account, &err)
+	return
+}
+
+// RateLimitBudget returns the zero budget unless a stub was added.
+func (m *Client) RateLimitBudget() (budget quoinex.RateLimitBudget) {
+	m.record("RateLimitBudget")
+	m.answer("RateLimitBudget", &budget)
+	return
+}
@@ -0,0 +1,94 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sho3imo/quoinex-go-client/v2"
+	"github.com/sho3imo/quoinex-go-client/v2/models"
+	"testing"
+)
+
+func TestScriptedResponses(t *testing.T) {
+	m := New()
+	var api quoinex.API = m
+	ctx := context.Background()
+	boom := errors.New("boom")
+	m.On("CancelAnOrder").Return(nil, boom)
+	m.On("CancelAnOrder").Return(&models.Order{ID: 1, Status: models.OrderStatusCancelled}, nil).Times(2)
+	m.On("CancelAnOrder").Return(&models.Order{ID: 2}, nil)
+
+	type Expect struct {
+		id  int
+		err error
+	}
+	cases := []struct {
+		expect Expect
+	}{
+		// test case 1
+		{expect: Expect{err: boom}},
+		// test case 2
+		{expect: Expect{id: 1}},
+		// test case 3
+		{expect: Expect{id: 1}},
+		// test case 4: the last stub repeats
+		{expect: Expect{id: 2}},
+		// test case 5
+		{expect: Expect{id: 2}},
+	}
+	for i, c := range cases {
+		order, err := api.CancelAnOrder(ctx, 100+i)
+		if err != c.expect.err {
+			t.Errorf("Wrong error. actual: %v, expect: %v", err, c.expect.err)
+		}
+		if (order == nil && c.expect.id != 0) || (order != nil && order.ID != c.expect.id) {
+			t.Errorf("Wrong order. actual: %+v, expect id: %d", order, c.expect.id)
+		}
+	}
+
+	if m.CallCount("CancelAnOrder") != 5 {
+		t.Errorf("Wrong call count. %d", m.CallCount("CancelAnOrder"))
+	}
+	if diff := cmp.Diff(Call{Method: "CancelAnOrder", Args: []interface{}{100}}, m.Calls()[0]); diff != "" {
+		t.Errorf("Wrong call. %s", diff)
+	}
+}
+
+func TestNotScripted(t *testing.T) {
+	m := New()
+	product, err := m.GetProduct(context.Background(), 5)
+	if product != nil || !errors.Is(err, ErrNotScripted) {
+		t.Errorf("Wrong result. product: %+v, err: %v", product, err)
+	}
+	if b := m.RateLimitBudget(); b != (quoinex.RateLimitBudget{}) {
+		t.Errorf("Wrong budget. %+v", b)
+	}
+}
+
+func TestWrongStubPanics(t *testing.T) {
+	m := New()
+	m.On("GetProduct").Return(&models.Order{}, nil)
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Wrong stub must panic")
+		}
+	}()
+	m.GetProduct(context.Background(), 5)
+}
+
+func TestIteratorPages(t *testing.T) {
+	m := New()
+	m.On("Orders").Return(&models.Orders{Models: []*models.Order{{ID: 1}, {ID: 2}}, CurrentPage: 1, TotalPages: 2}, nil)
+	m.On("Orders").Return(&models.Orders{Models: []*models.Order{{ID: 3}}, CurrentPage: 2, TotalPages: 2}, nil)
+
+	orders, err := m.Orders(context.Background(), quoinex.OrderFilter{ProductID: 5}).All()
+	if err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	if len(orders) != 3 || orders[2].ID != 3 {
+		t.Errorf("Wrong orders. %+v", orders)
+	}
+	if diff := cmp.Diff([]Call{{Method: "Orders", Args: []interface{}{quoinex.OrderFilter{ProductID: 5}}}}, m.Calls()); diff != "" {
+		t.Errorf("Wrong calls. %s", diff)
+	}
+}
@@ -1,6 +1,6 @@
 package models
 
 type AccountBalance struct {
-	Currency string `json:"currency"`
-	Balance  string `json:"balance"`
+	Currency string  `json:"currency"`
+	Balance  Decimal `json:"balance"`
 }
@@ -0,0 +1,20 @@
+package models
+
+// BankAccount is a bank account registered for fiat withdrawals.
+type BankAccount struct {
+	ID            int       `json:"id"`
+	Currency      string    `json:"currency"`
+	BankName      string    `json:"bank_name"`
+	BranchName    string    `json:"branch_name"`
+	AccountNumber string    `json:"account_number"`
+	HolderName    string    `json:"holder_name"`
+	SwiftCode     string    `json:"swift_code"`
+	Country       string    `json:"country"`
+	CreatedAt     Timestamp `json:"created_at"`
+}
+
+type BankAccounts struct {
+	Models      []*BankAccount `json:"models"`
+	CurrentPage int            `json:"current_page"`
+	TotalPages  int            `json:"total_pages"`
+}
@@ -2,13 +2,13 @@ package models
 
 type CryptoAccount struct {
 	ID                       int     `json:"id"`
-	Balance                  string  `json:"balance"`
+	Balance                  Decimal `json:"balance"`
 	Address                  string  `json:"address"`
 	Currency                 string  `json:"currency"`
 	CurrencySymbol           string  `json:"currency_symbol"`
 	PusherChannel            string  `json:"pusher_channel"`
-	MinimumWithdraw          float64 `json:"minimum_withdraw"`
-	LowestOfferInterestRate  string  `json:"lowest_offer_interest_rate"`
-	HighestOfferInterestRate string  `json:"highest_offer_interest_rate"`
+	MinimumWithdraw          Decimal `json:"minimum_withdraw"`
+	LowestOfferInterestRate  Decimal `json:"lowest_offer_interest_rate"`
+	HighestOfferInterestRate Decimal `json:"highest_offer_interest_rate"`
 	CurrencyType             string  `json:"currency_type"`
 }
@@ -0,0 +1,32 @@
+package models
+
+type CryptoWithdrawal struct {
+	ID              int             `json:"id"`
+	Address         string          `json:"address"`
+	Amount          Decimal         `json:"amount"`
+	Currency        string          `json:"currency"`
+	State           WithdrawalState `json:"state"`
+	WithdrawalFee   Decimal         `json:"withdrawal_fee"`
+	PaymentID       string          `json:"payment_id"`
+	Network         string          `json:"network"`
+	TransactionHash string          `json:"transaction_hash"`
+	CreatedAt       Timestamp       `json:"created_at"`
+	UpdatedAt       Timestamp       `json:"updated_at"`
+}
+
+type CryptoWithdrawals struct {
+	Models      []*CryptoWithdrawal `json:"models"`
+	CurrentPage int                 `json:"current_page"`
+	TotalPages  int                 `json:"total_pages"`
+}
+
+// WithdrawalAddress is an address registered for crypto withdrawals.
+type WithdrawalAddress struct {
+	ID        int       `json:"id"`
+	Address   string    `json:"address"`
+	Currency  string    `json:"currency"`
+	Label     string    `json:"label"`
+	PaymentID string    `json:"payment_id"`
+	Network   string    `json:"network"`
+	CreatedAt Timestamp `json:"created_at"`
+}
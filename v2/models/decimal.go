@@ -0,0 +1,269 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// maxDecimalExponent bounds the exponent accepted by NewDecimal so that a
+// hostile "1e999999999" cannot allocate a gigabyte of zeros.
+const maxDecimalExponent = 1000
+
+// Decimal is an exact base-10 number used for prices, quantities and
+// balances. The zero value is 0. Values keep the number of fraction digits
+// they were created with, so "500.0" marshals back as "500.0".
+type Decimal struct {
+	s string
+}
+
+// NewDecimal parses s, which may be an integer, a decimal fraction or use
+// exponent notation such as "1e-8".
+func NewDecimal(s string) (Decimal, error) {
+	coef, scale, err := parseDecimal(s)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return newDecimal(coef, scale), nil
+}
+
+// MustDecimal is like NewDecimal but panics if s is not a number.
+func MustDecimal(s string) Decimal {
+	d, err := NewDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// NewDecimalFromInt returns i as a Decimal.
+func NewDecimalFromInt(i int64) Decimal {
+	return newDecimal(big.NewInt(i), 0)
+}
+
+// NewDecimalFromFloat returns the shortest decimal that round-trips to f.
+func NewDecimalFromFloat(f float64) Decimal {
+	return MustDecimal(strconv.FormatFloat(f, 'g', -1, 64))
+}
+
+func parseDecimal(s string) (*big.Int, int, error) {
+	text := s
+	exp := 0
+	if i := strings.IndexAny(text, "eE"); i >= 0 {
+		e, err := strconv.Atoi(text[i+1:])
+		if err != nil || e > maxDecimalExponent || e < -maxDecimalExponent {
+			return nil, 0, fmt.Errorf("invalid decimal %q", s)
+		}
+		text, exp = text[:i], e
+	}
+	neg := false
+	if text != "" && (text[0] == '-' || text[0] == '+') {
+		neg = text[0] == '-'
+		text = text[1:]
+	}
+	intPart, frac := text, ""
+	if i := strings.IndexByte(text, '.'); i >= 0 {
+		intPart, frac = text[:i], text[i+1:]
+	}
+	digits := intPart + frac
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return nil, 0, fmt.Errorf("invalid decimal %q", s)
+	}
+	scale := len(frac) - exp
+	if scale < 0 {
+		digits += strings.Repeat("0", -scale)
+		scale = 0
+	}
+	coef, _ := new(big.Int).SetString(digits, 10)
+	if neg {
+		coef.Neg(coef)
+	}
+	return coef, scale, nil
+}
+
+func newDecimal(coef *big.Int, scale int) Decimal {
+	if coef.Sign() == 0 && scale == 0 {
+		return Decimal{}
+	}
+	digits := new(big.Int).Abs(coef).String()
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+	s := digits
+	if scale > 0 {
+		s = digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+	}
+	if coef.Sign() < 0 {
+		s = "-" + s
+	}
+	return Decimal{s: s}
+}
+
+func (d Decimal) parts() (*big.Int, int) {
+	if d.s == "" {
+		return new(big.Int), 0
+	}
+	coef, scale, _ := parseDecimal(d.s)
+	return coef, scale
+}
+
+// rescale returns the coefficients of d and o at their common scale.
+func (d Decimal) rescale(o Decimal) (*big.Int, *big.Int, int) {
+	a, as := d.parts()
+	b, bs := o.parts()
+	for ; as < bs; as++ {
+		a.Mul(a, big.NewInt(10))
+	}
+	for ; bs < as; bs++ {
+		b.Mul(b, big.NewInt(10))
+	}
+	return a, b, as
+}
+
+func (d Decimal) String() string {
+	if d.s == "" {
+		return "0"
+	}
+	return d.s
+}
+
+// Float64 returns the nearest float64 to d.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int {
+	_, scale := d.parts()
+	return scale
+}
+
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of d.
+func (d Decimal) Sign() int {
+	coef, _ := d.parts()
+	return coef.Sign()
+}
+
+// Cmp returns -1, 0 or +1 as d is less than, equal to or greater than o.
+func (d Decimal) Cmp(o Decimal) int {
+	a, b, _ := d.rescale(o)
+	return a.Cmp(b)
+}
+
+// Equal reports whether d and o are numerically equal, so 1.0 equals 1.00.
+func (d Decimal) Equal(o Decimal) bool {
+	return d.Cmp(o) == 0
+}
+
+func (d Decimal) LessThan(o Decimal) bool {
+	return d.Cmp(o) < 0
+}
+
+func (d Decimal) GreaterThan(o Decimal) bool {
+	return d.Cmp(o) > 0
+}
+
+func (d Decimal) Add(o Decimal) Decimal {
+	a, b, scale := d.rescale(o)
+	return newDecimal(a.Add(a, b), scale)
+}
+
+func (d Decimal) Sub(o Decimal) Decimal {
+	a, b, scale := d.rescale(o)
+	return newDecimal(a.Sub(a, b), scale)
+}
+
+func (d Decimal) Mul(o Decimal) Decimal {
+	a, as := d.parts()
+	b, bs := o.parts()
+	return newDecimal(a.Mul(a, b), as+bs)
+}
+
+// Div returns d / o rounded half away from zero to places fraction digits;
+// a negative places is taken as 0. It panics if o is zero.
+func (d Decimal) Div(o Decimal, places int) Decimal {
+	places = clampPlaces(places)
+	a, as := d.parts()
+	b, bs := o.parts()
+	if b.Sign() == 0 {
+		panic("models: division by zero")
+	}
+	// a/10^as / (b/10^bs) = a*10^(bs+places+1) / (b*10^as) at scale places+1
+	num := new(big.Int).Mul(a, pow10(bs+places+1))
+	den := new(big.Int).Mul(b, pow10(as))
+	q := num.Quo(num, den)
+	return newDecimal(q, places+1).Round(places)
+}
+
+// Round returns d rounded half away from zero to places fraction digits;
+// a negative places is taken as 0.
+func (d Decimal) Round(places int) Decimal {
+	places = clampPlaces(places)
+	coef, scale := d.parts()
+	if scale <= places {
+		return newDecimal(coef.Mul(coef, pow10(places-scale)), places)
+	}
+	div := pow10(scale - places)
+	q, r := new(big.Int).QuoRem(coef, div, new(big.Int))
+	if r.Abs(r).Mul(r, big.NewInt(2)).Cmp(div) >= 0 {
+		q.Add(q, big.NewInt(int64(coef.Sign())))
+	}
+	return newDecimal(q, places)
+}
+
+func (d Decimal) Neg() Decimal {
+	coef, scale := d.parts()
+	return newDecimal(coef.Neg(coef), scale)
+}
+
+func (d Decimal) Abs() Decimal {
+	coef, scale := d.parts()
+	return newDecimal(coef.Abs(coef), scale)
+}
+
+func clampPlaces(places int) int {
+	if places < 0 {
+		return 0
+	}
+	return places
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// MarshalJSON encodes d as a JSON string, the way the API sends numbers.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON accepts a JSON string or number. null and "" leave d unchanged.
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+	s := string(b)
+	if len(b) > 0 && b[0] == '"' {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		if s == "" {
+			return nil
+		}
+	}
+	v, err := NewDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDecimalUnmarshalJSON(t *testing.T) {
+	type Param struct {
+		json string
+	}
+	type Expect struct {
+		value   string
+		marshal string
+		err     bool
+	}
+	cases := []struct {
+		param  Param
+		expect Expect
+	}{
+		// test case 1: strings keep their fraction digits
+		{param: Param{json: `"500.0"`}, expect: Expect{value: "500.0", marshal: `"500.0"`}},
+		// test case 2: numbers do not go through float64
+		{param: Param{json: `2915.627366519999999998`}, expect: Expect{value: "2915.627366519999999998", marshal: `"2915.627366519999999998"`}},
+		// test case 3
+		{param: Param{json: `1e-8`}, expect: Expect{value: "0.00000001", marshal: `"0.00000001"`}},
+		// test case 4
+		{param: Param{json: `"-0.50"`}, expect: Expect{value: "-0.50", marshal: `"-0.50"`}},
+		// test case 5
+		{param: Param{json: `null`}, expect: Expect{value: "0", marshal: `"0"`}},
+		// test case 6
+		{param: Param{json: `""`}, expect: Expect{value: "0", marshal: `"0"`}},
+		// test case 7
+		{param: Param{json: `"abc"`}, expect: Expect{err: true}},
+		// test case 8
+		{param: Param{json: `"1e999999"`}, expect: Expect{err: true}},
+	}
+	for _, c := range cases {
+		var d Decimal
+		err := json.Unmarshal([]byte(c.param.json), &d)
+		if (err != nil) != c.expect.err {
+			t.Errorf("Wrong error for %s. %+v", c.param.json, err)
+			continue
+		}
+		if c.expect.err {
+			continue
+		}
+		if d.String() != c.expect.value {
+			t.Errorf("Wrong value. actual: %s, expect: %s", d.String(), c.expect.value)
+		}
+		b, _ := json.Marshal(d)
+		if string(b) != c.expect.marshal {
+			t.Errorf("Wrong marshal. actual: %s, expect: %s", b, c.expect.marshal)
+		}
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	type Param struct {
+		got Decimal
+	}
+	type Expect struct {
+		value string
+	}
+	a, b := MustDecimal("0.1"), MustDecimal("0.2")
+	cases := []struct {
+		param  Param
+		expect Expect
+	}{
+		// test case 1: no float drift
+		{param: Param{got: a.Add(b)}, expect: Expect{value: "0.3"}},
+		// test case 2
+		{param: Param{got: a.Sub(b)}, expect: Expect{value: "-0.1"}},
+		// test case 3
+		{param: Param{got: MustDecimal("1.5").Mul(MustDecimal("-0.25"))}, expect: Expect{value: "-0.375"}},
+		// test case 4
+		{param: Param{got: MustDecimal("1").Div(MustDecimal("3"), 4)}, expect: Expect{value: "0.3333"}},
+		// test case 5
+		{param: Param{got: MustDecimal("2").Div(MustDecimal("3"), 2)}, expect: Expect{value: "0.67"}},
+		// test case 6
+		{param: Param{got: MustDecimal("-2.345").Round(2)}, expect: Expect{value: "-2.35"}},
+		// test case 7
+		{param: Param{got: MustDecimal("7").Round(2)}, expect: Expect{value: "7.00"}},
+		// test case 8
+		{param: Param{got: MustDecimal("-3.10").Abs().Neg()}, expect: Expect{value: "-3.10"}},
+		// test case 9
+		{param: Param{got: NewDecimalFromFloat(0.0001)}, expect: Expect{value: "0.0001"}},
+		// test case 10
+		{param: Param{got: NewDecimalFromInt(-42)}, expect: Expect{value: "-42"}},
+		// test case 11: negative places round to an integer
+		{param: Param{got: MustDecimal("123.45").Round(-1)}, expect: Expect{value: "123"}},
+		// test case 12
+		{param: Param{got: MustDecimal("2").Div(MustDecimal("3"), -2)}, expect: Expect{value: "1"}},
+	}
+	for _, c := range cases {
+		if c.param.got.String() != c.expect.value {
+			t.Errorf("Wrong value. actual: %s, expect: %s", c.param.got.String(), c.expect.value)
+		}
+	}
+}
+
+func TestDecimalCmp(t *testing.T) {
+	if !MustDecimal("1.0").Equal(MustDecimal("1.00")) {
+		t.Errorf("1.0 must equal 1.00")
+	}
+	if !MustDecimal("-0.01").LessThan(Decimal{}) || !MustDecimal("0.01").GreaterThan(Decimal{}) {
+		t.Errorf("Wrong comparison with zero")
+	}
+	if !MustDecimal("0.000").IsZero() || MustDecimal("0.000").Scale() != 3 {
+		t.Errorf("Wrong zero")
+	}
+}
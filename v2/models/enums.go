@@ -0,0 +1,189 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// InvalidEnumError is returned when a value is not one of the constants of
+// its enumerated type.
+type InvalidEnumError struct {
+	Type    string
+	Value   string
+	Allowed []string
+}
+
+func (e *InvalidEnumError) Error() string {
+	return fmt.Sprintf("invalid %s %q: must be one of %s", e.Type, e.Value, strings.Join(e.Allowed, ", "))
+}
+
+// validateEnum accepts the empty string, which request builders use for an
+// omitted field.
+func validateEnum(typ, value string, allowed ...string) error {
+	if value == "" {
+		return nil
+	}
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+	}
+	return &InvalidEnumError{Type: typ, Value: value, Allowed: allowed}
+}
+
+type Side string
+
+const (
+	SideBuy  Side = "buy"
+	SideSell Side = "sell"
+)
+
+func (s Side) Validate() error {
+	return validateEnum("side", string(s), string(SideBuy), string(SideSell))
+}
+
+// TradeSide is the direction of a margin trade.
+type TradeSide string
+
+const (
+	TradeSideLong  TradeSide = "long"
+	TradeSideShort TradeSide = "short"
+)
+
+func (s TradeSide) Validate() error {
+	return validateEnum("trade side", string(s), string(TradeSideLong), string(TradeSideShort))
+}
+
+type OrderType string
+
+const (
+	OrderTypeLimit           OrderType = "limit"
+	OrderTypeMarket          OrderType = "market"
+	OrderTypeMarketWithRange OrderType = "market_with_range"
+	OrderTypeLimitPostOnly   OrderType = "limit_post_only"
+	OrderTypeStop            OrderType = "stop"
+	OrderTypeTrailingStop    OrderType = "trailing_stop"
+)
+
+func (t OrderType) Validate() error {
+	return validateEnum("order type", string(t), string(OrderTypeLimit), string(OrderTypeMarket), string(OrderTypeMarketWithRange),
+		string(OrderTypeLimitPostOnly), string(OrderTypeStop), string(OrderTypeTrailingStop))
+}
+
+type OrderStatus string
+
+const (
+	OrderStatusLive            OrderStatus = "live"
+	OrderStatusFilled          OrderStatus = "filled"
+	OrderStatusPartiallyFilled OrderStatus = "partially_filled"
+	OrderStatusCancelled       OrderStatus = "cancelled"
+)
+
+func (s OrderStatus) Validate() error {
+	return validateEnum("order status", string(s), string(OrderStatusLive), string(OrderStatusFilled),
+		string(OrderStatusPartiallyFilled), string(OrderStatusCancelled))
+}
+
+type TradeStatus string
+
+const (
+	TradeStatusOpen   TradeStatus = "open"
+	TradeStatusClosed TradeStatus = "closed"
+)
+
+func (s TradeStatus) Validate() error {
+	return validateEnum("trade status", string(s), string(TradeStatusOpen), string(TradeStatusClosed))
+}
+
+type LoanBidStatus string
+
+const (
+	LoanBidStatusLive   LoanBidStatus = "live"
+	LoanBidStatusFilled LoanBidStatus = "filled"
+	LoanBidStatusClosed LoanBidStatus = "closed"
+)
+
+func (s LoanBidStatus) Validate() error {
+	return validateEnum("loan bid status", string(s), string(LoanBidStatusLive), string(LoanBidStatusFilled), string(LoanBidStatusClosed))
+}
+
+type MarginType string
+
+const (
+	MarginTypeCross    MarginType = "cross"
+	MarginTypeIsolated MarginType = "isolated"
+)
+
+func (t MarginType) Validate() error {
+	return validateEnum("margin type", string(t), string(MarginTypeCross), string(MarginTypeIsolated))
+}
+
+type TradingType string
+
+const (
+	TradingTypeSpot      TradingType = "spot"
+	TradingTypeMargin    TradingType = "margin"
+	TradingTypeCFD       TradingType = "cfd"
+	TradingTypePerpetual TradingType = "perpetual"
+)
+
+func (t TradingType) Validate() error {
+	return validateEnum("trading type", string(t), string(TradingTypeSpot), string(TradingTypeMargin),
+		string(TradingTypeCFD), string(TradingTypePerpetual))
+}
+
+// TrailingStopType says whether a trailing stop value is an amount in the
+// quoted currency or a percentage of the price.
+type TrailingStopType string
+
+const (
+	TrailingStopTypeFiat       TrailingStopType = "fiat"
+	TrailingStopTypePercentage TrailingStopType = "percentage"
+)
+
+func (t TrailingStopType) Validate() error {
+	return validateEnum("trailing stop type", string(t), string(TrailingStopTypeFiat), string(TrailingStopTypePercentage))
+}
+
+// OrderDirection controls how a margin order affects open positions.
+type OrderDirection string
+
+const (
+	OrderDirectionOne    OrderDirection = "one_direction"
+	OrderDirectionTwo    OrderDirection = "two_direction"
+	OrderDirectionNetout OrderDirection = "netout"
+)
+
+func (d OrderDirection) Validate() error {
+	return validateEnum("order direction", string(d), string(OrderDirectionOne), string(OrderDirectionTwo), string(OrderDirectionNetout))
+}
+
+// WithdrawalState is the state of a crypto or fiat withdrawal. Only a
+// pending withdrawal can be cancelled.
+type WithdrawalState string
+
+const (
+	WithdrawalStatePending    WithdrawalState = "pending"
+	WithdrawalStateApproved   WithdrawalState = "approved"
+	WithdrawalStateProcessing WithdrawalState = "processing"
+	WithdrawalStateProcessed  WithdrawalState = "processed"
+	WithdrawalStateDeclined   WithdrawalState = "declined"
+	WithdrawalStateCancelled  WithdrawalState = "cancelled"
+)
+
+func (s WithdrawalState) Validate() error {
+	return validateEnum("withdrawal state", string(s), string(WithdrawalStatePending), string(WithdrawalStateApproved),
+		string(WithdrawalStateProcessing), string(WithdrawalStateProcessed), string(WithdrawalStateDeclined), string(WithdrawalStateCancelled))
+}
+
+type DepositState string
+
+const (
+	DepositStatePending   DepositState = "pending"
+	DepositStateProcessed DepositState = "processed"
+	DepositStateDeclined  DepositState = "declined"
+)
+
+func (s DepositState) Validate() error {
+	return validateEnum("deposit state", string(s), string(DepositStatePending), string(DepositStateProcessed), string(DepositStateDeclined))
+}
@@ -7,10 +7,10 @@ type Executions struct {
 }
 
 type ExecutionsModels struct {
-	ID        int    `json:"id"`
-	Quantity  string `json:"quantity"`
-	Price     string `json:"price"`
-	TakerSide string `json:"taker_side"`
-	MySide    string `json:"my_side"`
-	CreatedAt int    `json:"created_at"`
+	ID        int       `json:"id"`
+	Quantity  Decimal   `json:"quantity"`
+	Price     Decimal   `json:"price"`
+	TakerSide Side      `json:"taker_side"`
+	MySide    Side      `json:"my_side"`
+	CreatedAt Timestamp `json:"created_at"`
 }
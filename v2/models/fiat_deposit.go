@@ -0,0 +1,19 @@
+package models
+
+type FiatDeposit struct {
+	ID            int          `json:"id"`
+	Currency      string       `json:"currency"`
+	Amount        Decimal      `json:"amount"`
+	DepositFee    Decimal      `json:"deposit_fee"`
+	State         DepositState `json:"state"`
+	BankAccountID int          `json:"bank_id"`
+	Reference     string       `json:"reference"`
+	CreatedAt     Timestamp    `json:"created_at"`
+	UpdatedAt     Timestamp    `json:"updated_at"`
+}
+
+type FiatDeposits struct {
+	Models      []*FiatDeposit `json:"models"`
+	CurrentPage int            `json:"current_page"`
+	TotalPages  int            `json:"total_pages"`
+}
@@ -0,0 +1,19 @@
+package models
+
+type FiatWithdrawal struct {
+	ID            int             `json:"id"`
+	Currency      string          `json:"currency"`
+	Amount        Decimal         `json:"amount"`
+	WithdrawalFee Decimal         `json:"withdrawal_fee"`
+	State         WithdrawalState `json:"state"`
+	BankAccountID int             `json:"bank_id"`
+	Reference     string          `json:"reference"`
+	CreatedAt     Timestamp       `json:"created_at"`
+	UpdatedAt     Timestamp       `json:"updated_at"`
+}
+
+type FiatWithdrawals struct {
+	Models      []*FiatWithdrawal `json:"models"`
+	CurrentPage int               `json:"current_page"`
+	TotalPages  int               `json:"total_pages"`
+}
@@ -1,6 +1,6 @@
 package models
 
 type InterestRates struct {
-	Bids [][]string    `json:"bids"`
-	Asks []interface{} `json:"asks"`
+	Bids [][]Decimal `json:"bids"`
+	Asks [][]Decimal `json:"asks"`
 }
@@ -1,15 +1,15 @@
 package models
 
 type LoanBid struct {
-	ID             int    `json:"id"`
-	BidaskType     string `json:"bidask_type"`
-	Quantity       string `json:"quantity"`
-	Currency       string `json:"currency"`
-	Side           string `json:"side"`
-	FilledQuantity string `json:"filled_quantity"`
-	Status         string `json:"status"`
-	Rate           string `json:"rate"`
-	UserID         int    `json:"user_id"`
+	ID             int           `json:"id"`
+	BidaskType     string        `json:"bidask_type"`
+	Quantity       Decimal       `json:"quantity"`
+	Currency       string        `json:"currency"`
+	Side           string        `json:"side"`
+	FilledQuantity Decimal       `json:"filled_quantity"`
+	Status         LoanBidStatus `json:"status"`
+	Rate           Decimal       `json:"rate"`
+	UserID         int           `json:"user_id"`
 }
 
 type LoanBids struct {
@@ -7,13 +7,13 @@ type Loans struct {
 }
 
 type Loan struct {
-	ID           int    `json:"id"`
-	Quantity     string `json:"quantity"`
-	Rate         string `json:"rate"`
-	CreatedAt    int    `json:"created_at"`
-	LenderID     int    `json:"lender_id"`
-	BorrowerID   int    `json:"borrower_id"`
-	Status       string `json:"status"`
-	Currency     string `json:"currency"`
-	FundReloaned bool   `json:"fund_reloaned"`
+	ID           int       `json:"id"`
+	Quantity     Decimal   `json:"quantity"`
+	Rate         Decimal   `json:"rate"`
+	CreatedAt    Timestamp `json:"created_at"`
+	LenderID     int       `json:"lender_id"`
+	BorrowerID   int       `json:"borrower_id"`
+	Status       string    `json:"status"`
+	Currency     string    `json:"currency"`
+	FundReloaned bool      `json:"fund_reloaned"`
 }
@@ -1,7 +1,5 @@
 package models
 
-import "encoding/json"
-
 type Orders struct {
 	Models      []*Order `json:"models"`
 	CurrentPage int      `json:"current_page"`
@@ -9,38 +7,46 @@ type Orders struct {
 }
 
 type Order struct {
-	ID                   int             `json:"id"`
-	OrderType            string          `json:"order_type"`
-	Quantity             string          `json:"quantity"`
-	DiscQuantity         string          `json:"disc_quantity"`
-	IcebergTotalQuantity string          `json:"iceberg_total_quantity"`
-	Side                 string          `json:"side"`
-	FilledQuantity       string          `json:"filled_quantity"`
-	Price                json.Number     `json:"price"`
-	CreatedAt            int             `json:"created_at"`
-	UpdatedAt            int             `json:"updated_at"`
-	Status               string          `json:"status"`
-	LeverageLevel        int             `json:"leverage_level"`
-	SourceExchange       string          `json:"source_exchange"`
-	ProductID            int             `json:"product_id"`
-	ProductCode          string          `json:"product_code"`
-	FundingCurrency      string          `json:"funding_currency"`
-	CurrencyPairCode     string          `json:"currency_pair_code"`
-	OrderFee             json.Number     `json:"order_fee"`
-	Executions           OrderExecutions `json:"executions"`
-	ClientOrderID        string          `json:"client_order_id"`
+	ID                   int              `json:"id"`
+	OrderType            OrderType        `json:"order_type"`
+	Quantity             Decimal          `json:"quantity"`
+	DiscQuantity         Decimal          `json:"disc_quantity"`
+	IcebergTotalQuantity Decimal          `json:"iceberg_total_quantity"`
+	Side                 Side             `json:"side"`
+	FilledQuantity       Decimal          `json:"filled_quantity"`
+	Price                Decimal          `json:"price"`
+	CreatedAt            Timestamp        `json:"created_at"`
+	UpdatedAt            Timestamp        `json:"updated_at"`
+	Status               OrderStatus      `json:"status"`
+	LeverageLevel        int              `json:"leverage_level"`
+	SourceExchange       string           `json:"source_exchange"`
+	ProductID            int              `json:"product_id"`
+	ProductCode          string           `json:"product_code"`
+	FundingCurrency      string           `json:"funding_currency"`
+	CurrencyPairCode     string           `json:"currency_pair_code"`
+	OrderFee             Decimal          `json:"order_fee"`
+	Executions           OrderExecutions  `json:"executions"`
+	ClientOrderID        string           `json:"client_order_id"`
+	TradingType          TradingType      `json:"trading_type"`
+	MarginType           MarginType       `json:"margin_type"`
+	OrderDirection       OrderDirection   `json:"order_direction"`
+	StopLoss             Decimal          `json:"stop_loss"`
+	TakeProfit           Decimal          `json:"take_profit"`
+	TrailingStopType     TrailingStopType `json:"trailing_stop_type"`
+	TrailingStopValue    Decimal          `json:"trailing_stop_value"`
 }
 
 func (m *Order) GetPrice() float64 {
-	p, _ := m.Price.Float64()
-	return p
+	return m.Price.Float64()
 }
 
-type OrderExecutions []struct {
-	ID        int    `json:"id"`
-	Quantity  string `json:"quantity"`
-	Price     string `json:"price"`
-	TakerSide string `json:"taker_side"`
-	MySide    string `json:"my_side"`
-	CreatedAt int    `json:"created_at"`
+type OrderExecutions []OrderExecution
+
+type OrderExecution struct {
+	ID        int       `json:"id"`
+	Quantity  Decimal   `json:"quantity"`
+	Price     Decimal   `json:"price"`
+	TakerSide Side      `json:"taker_side"`
+	MySide    Side      `json:"my_side"`
+	CreatedAt Timestamp `json:"created_at"`
 }
@@ -1,21 +1,16 @@
 package models
 
-import (
-	"encoding/json"
-	"sort"
-)
+import "sort"
 
 type PriceLevels struct {
-	BuyPriceLevels  [][]json.Number `json:"buy_price_levels"`
-	SellPriceLevels [][]json.Number `json:"sell_price_levels"`
+	BuyPriceLevels  [][]Decimal `json:"buy_price_levels"`
+	SellPriceLevels [][]Decimal `json:"sell_price_levels"`
 }
 
 func (p *PriceLevels) GetSellPriceLevelsFloat64() [][]float64 {
 	var sellFloat64 [][]float64
 	for _, s := range p.SellPriceLevels {
-		a, _ := s[0].Float64()
-		b, _ := s[1].Float64()
-		sellFloat64 = append(sellFloat64, []float64{a, b})
+		sellFloat64 = append(sellFloat64, []float64{s[0].Float64(), s[1].Float64()})
 	}
 	return sellFloat64
 }
@@ -23,9 +18,7 @@ func (p *PriceLevels) GetSellPriceLevelsFloat64() [][]float64 {
 func (p *PriceLevels) GetBuyPriceLevelsFloat64() [][]float64 {
 	var buyFloat64 [][]float64
 	for _, buy := range p.BuyPriceLevels {
-		a, _ := buy[0].Float64()
-		b, _ := buy[1].Float64()
-		buyFloat64 = append(buyFloat64, []float64{a, b})
+		buyFloat64 = append(buyFloat64, []float64{buy[0].Float64(), buy[1].Float64()})
 	}
 	return buyFloat64
 }
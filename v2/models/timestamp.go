@@ -0,0 +1,101 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Timestamp is a Unix epoch as sent by the API. It accepts integer, float
+// and string epochs and exposes the value as a UTC time.Time.
+type Timestamp struct {
+	time.Time
+}
+
+// NewTimestamp wraps t.
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp{Time: t.UTC()}
+}
+
+// NewTimestampFromUnix returns the Timestamp sec seconds after the epoch.
+func NewTimestampFromUnix(sec int64) Timestamp {
+	return Timestamp{Time: time.Unix(sec, 0).UTC()}
+}
+
+func parseEpoch(s string) (time.Time, error) {
+	if strings.ContainsAny(s, "eE") {
+		// an exponent float, as encoding/json writes large float64s
+		r, ok := new(big.Rat).SetString(s)
+		if !ok || strings.Contains(s, "/") {
+			return time.Time{}, fmt.Errorf("invalid timestamp %q", s)
+		}
+		s = r.FloatString(9)
+	}
+	secText, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		secText, frac = s[:i], s[i+1:]
+	}
+	sec, err := strconv.ParseInt(secText, 10, 64)
+	if err != nil || strings.Trim(frac, "0123456789") != "" {
+		return time.Time{}, fmt.Errorf("invalid timestamp %q", s)
+	}
+	if len(frac) > 9 {
+		frac = frac[:9]
+	}
+	var nsec int64
+	if frac != "" {
+		nsec, _ = strconv.ParseInt(frac+strings.Repeat("0", 9-len(frac)), 10, 64)
+		if strings.HasPrefix(secText, "-") {
+			nsec = -nsec
+		}
+	}
+	return time.Unix(sec, nsec).UTC(), nil
+}
+
+// MarshalJSON encodes t as integer seconds, with a fraction only when t
+// has sub-second precision. The zero Timestamp encodes as null.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	sec, ns := t.Unix(), t.Nanosecond()
+	if ns == 0 {
+		return []byte(strconv.FormatInt(sec, 10)), nil
+	}
+	sign := ""
+	if sec < 0 {
+		// Unix rounds toward -inf: -1.25s is -2 seconds and 750ms
+		sign, sec, ns = "-", -(sec + 1), 1e9-ns
+	}
+	s := sign + strconv.FormatInt(sec, 10) + strings.TrimRight(fmt.Sprintf(".%09d", ns), "0")
+	return []byte(s), nil
+}
+
+// UnmarshalJSON accepts 1457370745, 1457370745.25, 1.457370745e9 or
+// "1457370745". null
+// and "" leave t unchanged.
+func (t *Timestamp) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+	s := string(b)
+	if len(b) > 0 && b[0] == '"' {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		if s == "" {
+			return nil
+		}
+	}
+	v, err := parseEpoch(s)
+	if err != nil {
+		return err
+	}
+	t.Time = v
+	return nil
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimestampUnmarshalJSON(t *testing.T) {
+	type Param struct {
+		json string
+	}
+	type Expect struct {
+		time    time.Time
+		marshal string
+		err     bool
+	}
+	cases := []struct {
+		param  Param
+		expect Expect
+	}{
+		// test case 1
+		{param: Param{json: `1457370745`}, expect: Expect{time: time.Unix(1457370745, 0), marshal: `1457370745`}},
+		// test case 2
+		{param: Param{json: `"1457370745"`}, expect: Expect{time: time.Unix(1457370745, 0), marshal: `1457370745`}},
+		// test case 3: fractions are kept exactly
+		{param: Param{json: `1457370745.123456`}, expect: Expect{time: time.Unix(1457370745, 123456000), marshal: `1457370745.123456`}},
+		// test case 4
+		{param: Param{json: `"1457370745.5"`}, expect: Expect{time: time.Unix(1457370745, 500000000), marshal: `1457370745.5`}},
+		// test case 5
+		{param: Param{json: `null`}, expect: Expect{marshal: `null`}},
+		// test case 6
+		{param: Param{json: `"2016-03-07"`}, expect: Expect{err: true}},
+		// test case 7: before 1970 with a fraction
+		{param: Param{json: `-0.5`}, expect: Expect{time: time.Unix(0, -500000000), marshal: `-0.5`}},
+		// test case 8
+		{param: Param{json: `-1.25`}, expect: Expect{time: time.Unix(-1, -250000000), marshal: `-1.25`}},
+		// test case 9
+		{param: Param{json: `-86400`}, expect: Expect{time: time.Unix(-86400, 0), marshal: `-86400`}},
+		// test case 10: exponent floats as encoding/json writes them
+		{param: Param{json: `1.4573707e9`}, expect: Expect{time: time.Unix(1457370700, 0), marshal: `1457370700`}},
+		// test case 11
+		{param: Param{json: `1.45737074525E+09`}, expect: Expect{time: time.Unix(1457370745, 250000000), marshal: `1457370745.25`}},
+		// test case 12
+		{param: Param{json: `"1/3e9"`}, expect: Expect{err: true}},
+	}
+	for _, c := range cases {
+		var ts Timestamp
+		err := json.Unmarshal([]byte(c.param.json), &ts)
+		if (err != nil) != c.expect.err {
+			t.Errorf("Wrong error for %s. %+v", c.param.json, err)
+			continue
+		}
+		if c.expect.err {
+			continue
+		}
+		if !ts.Time.Equal(c.expect.time) {
+			t.Errorf("Wrong time. actual: %v, expect: %v", ts.Time, c.expect.time)
+		}
+		if !ts.IsZero() && ts.Location() != time.UTC {
+			t.Errorf("Wrong location. %v", ts.Location())
+		}
+		b, _ := json.Marshal(ts)
+		if string(b) != c.expect.marshal {
+			t.Errorf("Wrong marshal. actual: %s, expect: %s", b, c.expect.marshal)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package quoinex
+
+import (
+	"sync/atomic"
+)
+
+// NonceSource supplies the nonce claim of the JWT sent with each request.
+// The exchange rejects a nonce that is not greater than the previous one.
+type NonceSource interface {
+	Nonce() int64
+}
+
+// MonotonicNonceSource returns clock.Now() in nanoseconds, bumped past the
+// last value it handed out so that nonces are strictly increasing even when
+// requests are signed concurrently or the wall clock steps backwards.
+type MonotonicNonceSource struct {
+	clock Clock
+	last  int64
+}
+
+func NewMonotonicNonceSource(clock Clock) *MonotonicNonceSource {
+	if clock == nil {
+		clock = systemClock{}
+	}
+	return &MonotonicNonceSource{clock: clock}
+}
+
+func (s *MonotonicNonceSource) Nonce() int64 {
+	for {
+		last := atomic.LoadInt64(&s.last)
+		n := s.clock.Now().UnixNano()
+		if n <= last {
+			n = last + 1
+		}
+		if atomic.CompareAndSwapInt64(&s.last, last, n) {
+			return n
+		}
+	}
+}
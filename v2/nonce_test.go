@@ -0,0 +1,123 @@
+package quoinex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/sho3imo/quoinex-go-client/v2/models"
+	"github.com/sho3imo/quoinex-go-client/v2/testutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+type steppingClock struct {
+	mu    sync.Mutex
+	times []time.Time
+}
+
+func (c *steppingClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := c.times[0]
+	if len(c.times) > 1 {
+		c.times = c.times[1:]
+	}
+	return t
+}
+
+func TestMonotonicNonceSource(t *testing.T) {
+	base := time.Unix(1500000000, 0)
+	cases := []struct {
+		times  []time.Time
+		expect []int64
+	}{
+		// test case 1: clock moves forward
+		{
+			times:  []time.Time{base, base.Add(time.Second)},
+			expect: []int64{base.UnixNano(), base.Add(time.Second).UnixNano()},
+		},
+		// test case 2: same instant
+		{
+			times:  []time.Time{base, base, base},
+			expect: []int64{base.UnixNano(), base.UnixNano() + 1, base.UnixNano() + 2},
+		},
+		// test case 3: clock steps backwards
+		{
+			times:  []time.Time{base, base.Add(-time.Hour), base.Add(time.Nanosecond)},
+			expect: []int64{base.UnixNano(), base.UnixNano() + 1, base.UnixNano() + 2},
+		},
+	}
+	for _, c := range cases {
+		source := NewMonotonicNonceSource(&steppingClock{times: c.times})
+		for i, e := range c.expect {
+			if n := source.Nonce(); n != e {
+				t.Errorf("Wrong nonce #%d. actual: %d, expect: %d", i, n, e)
+			}
+		}
+	}
+}
+
+func TestNonceUniqueUnderParallelLoad(t *testing.T) {
+	var mu sync.Mutex
+	nonces := map[int64]int{}
+	ts := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			parser := &jwt.Parser{UseJSONNumber: true}
+			token, err := parser.Parse(r.Header.Get("X-Quoine-Auth"), func(*jwt.Token) (interface{}, error) {
+				return []byte("secret"), nil
+			})
+			if err != nil {
+				t.Errorf("Wrong token. %+v", err)
+				return
+			}
+			nonce, _ := token.Claims.(jwt.MapClaims)["nonce"].(json.Number).Int64()
+			mu.Lock()
+			nonces[nonce]++
+			mu.Unlock()
+
+			if r.Method == "POST" {
+				fmt.Fprint(w, testutil.GetCreateAnOrderJsonResponse())
+			} else {
+				fmt.Fprint(w, testutil.GetOrderBookJsonResponse())
+			}
+		},
+	))
+	defer ts.Close()
+
+	// a frozen clock forces every nonce through the collision path
+	clock := &steppingClock{times: []time.Time{time.Unix(1500000000, 0)}}
+	client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL), WithClock(clock), WithRateLimiter(nil))
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	const workers, perWorker = 32, 25
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < perWorker; j++ {
+				var err error
+				if (i+j)%2 == 0 {
+					_, err = client.GetOrderBook(ctx, 1, false)
+				} else {
+					_, err = client.CreateAnOrder(ctx, "limit", "sell", models.MustDecimal("0.01"), models.MustDecimal("500.0"), models.Decimal{}, 1, "")
+				}
+				if err != nil {
+					t.Errorf("Error. %+v", err)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(nonces) != workers*perWorker {
+		t.Errorf("Wrong distinct nonces. actual: %d, expect: %d", len(nonces), workers*perWorker)
+	}
+}
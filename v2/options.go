@@ -0,0 +1,132 @@
+package quoinex
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ClientOption configures a Client in NewClient.
+type ClientOption func(*Client) error
+
+// Clock is the time source used to sign requests.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		u, err := url.ParseRequestURI(baseURL)
+		if err != nil {
+			return err
+		}
+		c.URL = u
+		return nil
+	}
+}
+
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) error {
+		if httpClient == nil {
+			return fmt.Errorf("httpClient is nil")
+		}
+		c.HTTPClient = httpClient
+		return nil
+	}
+}
+
+// WithTimeout sets the timeout on a copy of the current HTTP client.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) error {
+		httpClient := *c.HTTPClient
+		httpClient.Timeout = timeout
+		c.HTTPClient = &httpClient
+		return nil
+	}
+}
+
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) error {
+		c.UserAgent = userAgent
+		return nil
+	}
+}
+
+// WithLogger sets a structured logger, e.g. NewStdLogger or NewSlogLogger.
+func WithLogger(logger Logger) ClientOption {
+	return func(c *Client) error {
+		if logger != nil {
+			c.Logger = logger
+		}
+		return nil
+	}
+}
+
+// WithRedactedFields masks additional JSON keys in debug body logs.
+func WithRedactedFields(fields ...string) ClientOption {
+	return func(c *Client) error {
+		c.RedactFields = append(c.RedactFields, fields...)
+		return nil
+	}
+}
+
+func WithClock(clock Clock) ClientOption {
+	return func(c *Client) error {
+		if clock == nil {
+			return fmt.Errorf("clock is nil")
+		}
+		c.Clock = clock
+		return nil
+	}
+}
+
+// WithNonceSource replaces the default MonotonicNonceSource, e.g. to share
+// one source between clients using the same API token.
+func WithNonceSource(source NonceSource) ClientOption {
+	return func(c *Client) error {
+		if source == nil {
+			return fmt.Errorf("nonce source is nil")
+		}
+		c.NonceSource = source
+		return nil
+	}
+}
+
+// WithRateLimiter replaces the default limiter; nil disables rate limiting.
+func WithRateLimiter(limiter *RateLimiter) ClientOption {
+	return func(c *Client) error {
+		c.RateLimiter = limiter
+		return nil
+	}
+}
+
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) error {
+		c.RetryPolicy = &policy
+		return nil
+	}
+}
+
+// WithMetrics reports request and rate-limit measurements to m.
+func WithMetrics(m Metrics) ClientOption {
+	return func(c *Client) error {
+		if m == nil {
+			return fmt.Errorf("metrics is nil")
+		}
+		c.Metrics = m
+		return nil
+	}
+}
+
+// WithMiddleware appends middlewares; the first one added is the outermost.
+func WithMiddleware(middlewares ...Middleware) ClientOption {
+	return func(c *Client) error {
+		c.Middlewares = append(c.Middlewares, middlewares...)
+		return nil
+	}
+}
@@ -2,139 +2,312 @@ package quoinex
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/sho3imo/quoinex-go-client/v2/models"
+	"net/url"
 	"strconv"
-	"strings"
+	"time"
 )
 
 func (c *Client) GetAnOrder(ctx context.Context, orderID int) (*models.Order, error) {
 	spath := fmt.Sprintf("/orders/%d", orderID)
-	res, err := c.sendRequest(ctx, "GET", spath, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
 	var order models.Order
-	if err := decodeBody(res, &order); err != nil {
+	if err := c.sendRequest(ctx, "GetAnOrder", "GET", spath, nil, nil, &order); err != nil {
 		return nil, err
 	}
 
 	return &order, nil
 }
 
-func (c *Client) GetOrders(ctx context.Context, productID, withDetails int, fundingCurrency, status string) (*models.Orders, error) {
-	spath := fmt.Sprintf("/orders")
-	queryParam := &map[string]string{
-		"product_id":       strconv.Itoa(productID),
-		"with_details":     strconv.Itoa(withDetails),
-		"status":           status,
-		"funding_currency": fundingCurrency}
-	res, err := c.sendRequest(ctx, "GET", spath, nil, queryParam)
-	if err != nil {
+func (c *Client) GetOrders(ctx context.Context, productID, withDetails int, fundingCurrency string, status models.OrderStatus) (*models.Orders, error) {
+	return c.GetOrdersByFilter(ctx, OrderFilter{
+		ProductID:       productID,
+		WithDetails:     withDetails == 1,
+		FundingCurrency: fundingCurrency,
+		Status:          status})
+}
+
+// GetOrdersByFilter returns one page of the orders matching filter: the
+// page of filter.Page, or the first. Use Orders to walk every page.
+func (c *Client) GetOrdersByFilter(ctx context.Context, filter OrderFilter) (*models.Orders, error) {
+	if err := filter.validate(); err != nil {
 		return nil, err
 	}
-
+	spath := fmt.Sprintf("/orders")
+	queryParam := filter.query()
+	filter.setPageQuery(queryParam)
 	var orders models.Orders
-	if err := decodeBody(res, &orders); err != nil {
+	if err := c.sendRequest(ctx, "GetOrders", "GET", spath, nil, &queryParam, &orders); err != nil {
 		return nil, err
 	}
 
 	return &orders, nil
 }
 
-func (c *Client) CreateAnOrder(ctx context.Context, orderType, side, quantity, price, priceRange string, productID int, clientOrderID string) (*models.Order, error) {
-	spath := fmt.Sprintf("/orders/")
+// OrderFilter selects the orders of GetOrdersByFilter and Orders. Zero
+// fields are not sent.
+type OrderFilter struct {
+	ProductID       int
+	WithDetails     bool
+	FundingCurrency string
+	Status          models.OrderStatus
+	Side            models.Side
+	TradingType     models.TradingType
+	ClientOrderID   string
+	// CreatedFrom and CreatedTo restrict created_at to [CreatedFrom, CreatedTo).
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	ListOptions
+}
 
-	var body string
-	if clientOrderID == "" {
-		bodyTemplate :=
-			`{
-			"order": {
-				"order_type":"%s",
-				"product_id":%d,
-				"side":"%s",
-				"quantity":"%s",
-				"price":"%s",
-				"price_range":"%s"
-			}
-		}`
-		body = fmt.Sprintf(bodyTemplate, orderType, productID, side, quantity, price, priceRange)
-	} else {
-		bodyTemplate :=
-			`{
-			"order": {
-				"order_type":"%s",
-				"product_id":%d,
-				"side":"%s",
-				"quantity":"%s",
-				"price":"%s",
-				"price_range":"%s",
-				"client_order_id": "%s"
-			}
-		}`
-		body = fmt.Sprintf(bodyTemplate, orderType, productID, side, quantity, price, priceRange, clientOrderID)
+func (f OrderFilter) validate() error {
+	if err := f.Status.Validate(); err != nil {
+		return err
+	}
+	if err := f.Side.Validate(); err != nil {
+		return err
+	}
+	if err := f.TradingType.Validate(); err != nil {
+		return err
+	}
+	if !f.CreatedFrom.IsZero() && !f.CreatedTo.IsZero() && !f.CreatedFrom.Before(f.CreatedTo) {
+		return fmt.Errorf("CreatedFrom %v is not before CreatedTo %v", f.CreatedFrom, f.CreatedTo)
+	}
+	return nil
+}
+
+func (f OrderFilter) query() map[string]string {
+	q := map[string]string{
+		"funding_currency": f.FundingCurrency,
+		"status":           string(f.Status),
+		"side":             string(f.Side),
+		"trading_type":     string(f.TradingType),
+		"client_order_id":  f.ClientOrderID}
+	if f.ProductID != 0 {
+		q["product_id"] = strconv.Itoa(f.ProductID)
+	}
+	if f.WithDetails {
+		q["with_details"] = "1"
+	}
+	if !f.CreatedFrom.IsZero() {
+		q["created_at_gte"] = strconv.FormatInt(f.CreatedFrom.Unix(), 10)
 	}
-	res, err := c.sendRequest(ctx, "POST", spath, strings.NewReader(body), nil)
+	if !f.CreatedTo.IsZero() {
+		q["created_at_lt"] = strconv.FormatInt(f.CreatedTo.Unix(), 10)
+	}
+	return q
+}
+
+// OrderIterator walks every order matching a filter, one page at a time.
+type OrderIterator = Iterator[*models.Order]
+
+// NewOrderIterator returns an iterator that loads each page with fetch.
+// It lets implementations of API other than Client return iterators.
+func NewOrderIterator(ctx context.Context, opts ListOptions, fetch func(ctx context.Context, page int) (*models.Orders, error)) *OrderIterator {
+	return newPageIterator(ctx, opts, fetch, func(p *models.Orders) ([]*models.Order, int) { return p.Models, p.TotalPages })
+}
+
+// Orders returns an iterator over every order matching filter. No request
+// is sent until Next is called.
+func (c *Client) Orders(ctx context.Context, filter OrderFilter) *OrderIterator {
+	it := NewOrderIterator(ctx, filter.ListOptions, func(ctx context.Context, page int) (*models.Orders, error) {
+		queryParam := filter.query()
+		filter.setQuery(queryParam, page)
+		var orders models.Orders
+		if err := c.sendRequest(ctx, "GetOrders", "GET", "/orders", nil, &queryParam, &orders); err != nil {
+			return nil, err
+		}
+		return &orders, nil
+	})
+	if err := filter.validate(); err != nil {
+		it.stop(err)
+	}
+	return it
+}
+
+func (c *Client) CreateAnOrder(ctx context.Context, orderType models.OrderType, side models.Side, quantity, price, priceRange models.Decimal, productID int, clientOrderID string) (*models.Order, error) {
+	return c.CreateOrder(ctx, &CreateOrderRequest{
+		OrderType:     orderType,
+		ProductID:     productID,
+		Side:          side,
+		Quantity:      quantity,
+		Price:         optionalDecimal(price),
+		PriceRange:    optionalDecimal(priceRange),
+		ClientOrderID: clientOrderID,
+	})
+}
+
+func (c *Client) CreateOrder(ctx context.Context, req *CreateOrderRequest) (*models.Order, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request is nil")
+	}
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+	spath := fmt.Sprintf("/orders/")
+	body, err := jsonBody(&orderEnvelope{Order: req})
 	if err != nil {
 		return nil, err
 	}
 
+	// a client_order_id makes the request safe to retry: a duplicate on a
+	// retry means an earlier attempt was accepted by the exchange.
+	reqCtx := ctx
+	state := &retryState{}
+	if req.ClientOrderID != "" {
+		reqCtx = withRetryState(ctx, state)
+	}
 	var order models.Order
-	if err := decodeBody(res, &order); err != nil {
+	if err := c.sendRequest(reqCtx, "CreateAnOrder", "POST", spath, body, nil, &order); err != nil {
+		if state.attempts > 1 && errors.Is(err, LiquidAlreadyExistError) {
+			o, ferr := c.findOrderByClientOrderID(withoutResponseMeta(ctx), req.ProductID, req.ClientOrderID)
+			if ferr == nil && o == nil {
+				ferr = fmt.Errorf("order with client_order_id %s not found", req.ClientOrderID)
+			}
+			return o, ferr
+		}
 		return nil, err
 	}
 
 	return &order, nil
 }
 
-func (c *Client) CancelAnOrder(ctx context.Context, orderID int) (*models.Order, error) {
-	spath := fmt.Sprintf("/orders/%d/cancel", orderID)
-	res, err := c.sendRequest(ctx, "PUT", spath, nil, nil)
+// findOrderByClientOrderID scans /orders page by page for clientOrderID. It
+// returns a nil order when none carries it.
+func (c *Client) findOrderByClientOrderID(ctx context.Context, productID int, clientOrderID string) (*models.Order, error) {
+	it := c.Orders(ctx, OrderFilter{ProductID: productID, ClientOrderID: clientOrderID})
+	for it.Next() {
+		if o := it.Item(); o.ClientOrderID == clientOrderID {
+			return o, nil
+		}
+	}
+	return nil, it.Err()
+}
+
+// scanOrderByClientID is the fallback of the *ByClientID methods when the
+// client-ID endpoint answers 404: it returns notFound unless the /orders
+// scan finds the order.
+func (c *Client) scanOrderByClientID(ctx context.Context, clientOrderID string, notFound error) (*models.Order, error) {
+	o, err := c.findOrderByClientOrderID(ctx, 0, clientOrderID)
 	if err != nil {
 		return nil, err
 	}
+	if o == nil {
+		return nil, notFound
+	}
+	return o, nil
+}
+
+func clientOrderPath(clientOrderID string) (string, error) {
+	if clientOrderID == "" {
+		return "", fmt.Errorf("clientOrderID is empty")
+	}
+	return fmt.Sprintf("/orders/client:%s", url.PathEscape(clientOrderID)), nil
+}
 
+// GetOrderByClientID returns the order created with clientOrderID.
+func (c *Client) GetOrderByClientID(ctx context.Context, clientOrderID string) (*models.Order, error) {
+	spath, err := clientOrderPath(clientOrderID)
+	if err != nil {
+		return nil, err
+	}
 	var order models.Order
-	if err := decodeBody(res, &order); err != nil {
+	if err := c.sendRequest(ctx, "GetOrderByClientID", "GET", spath, nil, nil, &order); err != nil {
+		if IsNotFound(err) {
+			// the scan answers the call, so it is what ResponseMeta records
+			return c.scanOrderByClientID(ctx, clientOrderID, err)
+		}
 		return nil, err
 	}
 
 	return &order, nil
 }
 
-func (c *Client) EditALiveOrder(ctx context.Context, orderID int, quantity, price string) (*models.Order, error) {
-	spath := fmt.Sprintf("/orders/%d", orderID)
-	bodyTemplate :=
-		`{
-			"order": {
-				"quantity":"%s",
-				"price":"%s",
-			}
-		}`
-	body := fmt.Sprintf(bodyTemplate, quantity, price)
-	res, err := c.sendRequest(ctx, "PUT", spath, strings.NewReader(body), nil)
+// CancelOrderByClientID cancels the order created with clientOrderID.
+func (c *Client) CancelOrderByClientID(ctx context.Context, clientOrderID string) (*models.Order, error) {
+	spath, err := clientOrderPath(clientOrderID)
 	if err != nil {
 		return nil, err
 	}
+	var order models.Order
+	if err := c.sendRequest(ctx, "CancelOrderByClientID", "PUT", spath+"/cancel", nil, nil, &order); err != nil {
+		if !IsNotFound(err) {
+			return nil, err
+		}
+		o, err := c.scanOrderByClientID(withoutResponseMeta(ctx), clientOrderID, err)
+		if err != nil {
+			return nil, err
+		}
+		return c.CancelAnOrder(ctx, o.ID)
+	}
+
+	return &order, nil
+}
 
+// EditOrderByClientID edits the live order created with clientOrderID.
+func (c *Client) EditOrderByClientID(ctx context.Context, clientOrderID string, req *EditOrderRequest) (*models.Order, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request is nil")
+	}
+	spath, err := clientOrderPath(clientOrderID)
+	if err != nil {
+		return nil, err
+	}
+	body, err := jsonBody(&orderEnvelope{Order: req})
+	if err != nil {
+		return nil, err
+	}
 	var order models.Order
-	if err := decodeBody(res, &order); err != nil {
+	if err := c.sendRequest(ctx, "EditOrderByClientID", "PUT", spath, body, nil, &order); err != nil {
+		if !IsNotFound(err) {
+			return nil, err
+		}
+		o, err := c.scanOrderByClientID(withoutResponseMeta(ctx), clientOrderID, err)
+		if err != nil {
+			return nil, err
+		}
+		return c.EditOrder(ctx, o.ID, req)
+	}
+
+	return &order, nil
+}
+
+func (c *Client) CancelAnOrder(ctx context.Context, orderID int) (*models.Order, error) {
+	spath := fmt.Sprintf("/orders/%d/cancel", orderID)
+	var order models.Order
+	if err := c.sendRequest(ctx, "CancelAnOrder", "PUT", spath, nil, nil, &order); err != nil {
 		return nil, err
 	}
 
 	return &order, nil
 }
 
-func (c *Client) GetAnOrderTrades(ctx context.Context, orderID int) ([]*models.Trade, error) {
-	spath := fmt.Sprintf("/orders/%d/trades", orderID)
-	res, err := c.sendRequest(ctx, "GET", spath, nil, nil)
+func (c *Client) EditALiveOrder(ctx context.Context, orderID int, quantity, price models.Decimal) (*models.Order, error) {
+	return c.EditOrder(ctx, orderID, &EditOrderRequest{Quantity: optionalDecimal(quantity), Price: optionalDecimal(price)})
+}
+
+func (c *Client) EditOrder(ctx context.Context, orderID int, req *EditOrderRequest) (*models.Order, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request is nil")
+	}
+	spath := fmt.Sprintf("/orders/%d", orderID)
+	body, err := jsonBody(&orderEnvelope{Order: req})
 	if err != nil {
 		return nil, err
 	}
+	var order models.Order
+	if err := c.sendRequest(ctx, "EditALiveOrder", "PUT", spath, body, nil, &order); err != nil {
+		return nil, err
+	}
 
+	return &order, nil
+}
+
+func (c *Client) GetAnOrderTrades(ctx context.Context, orderID int) ([]*models.Trade, error) {
+	spath := fmt.Sprintf("/orders/%d/trades", orderID)
 	var trades []*models.Trade
-	if err := decodeBody(res, &trades); err != nil {
+	if err := c.sendRequest(ctx, "GetAnOrderTrades", "GET", spath, nil, nil, &trades); err != nil {
 		return nil, err
 	}
 
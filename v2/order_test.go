@@ -36,8 +36,7 @@ func TestGetAnOrder(t *testing.T) {
 		ts := testutil.GenerateTestServer(t, c.expect.path, c.expect.method, c.expect.body, c.param.jsonResponse)
 		defer ts.Close()
 
-		client, _ := NewClient("apiTokenID", "secret", nil)
-		client.testServer = ts
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		order, _ := client.GetAnOrder(ctx, 1)
@@ -52,7 +51,7 @@ func TestGetOrders(t *testing.T) {
 		productID       int
 		withDetails     int
 		fundingCurrency string
-		status          string
+		status          models.OrderStatus
 		jsonResponse    string
 	}
 	type Expect struct {
@@ -67,8 +66,8 @@ func TestGetOrders(t *testing.T) {
 	}{
 		// test case 1
 		{
-			param:  Param{productID: 1, withDetails: 1, fundingCurrency: "USD", status: "ok", jsonResponse: testutil.GetOrdersJsonResponse()},
-			expect: Expect{path: "/orders?funding_currency=USD&product_id=1&status=ok&with_details=1", method: "GET", body: "", e: testutil.GetExpectedOrdersModel()},
+			param:  Param{productID: 1, withDetails: 1, fundingCurrency: "USD", status: "live", jsonResponse: testutil.GetOrdersJsonResponse()},
+			expect: Expect{path: "/orders?funding_currency=USD&product_id=1&status=live&with_details=1", method: "GET", body: "", e: testutil.GetExpectedOrdersModel()},
 		},
 		// test case 2
 	}
@@ -77,8 +76,7 @@ func TestGetOrders(t *testing.T) {
 		ts := testutil.GenerateTestServer(t, c.expect.path, c.expect.method, c.expect.body, c.param.jsonResponse)
 		defer ts.Close()
 
-		client, _ := NewClient("apiTokenID", "secret", nil)
-		client.testServer = ts
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		r, _ := client.GetOrders(ctx, c.param.productID, c.param.withDetails, c.param.fundingCurrency, c.param.status)
@@ -90,12 +88,12 @@ func TestGetOrders(t *testing.T) {
 
 func TestCreateAnOrder(t *testing.T) {
 	type Param struct {
-		orderType    string
+		orderType    models.OrderType
 		productID    int
-		side         string
-		quantity     string
-		price        string
-		priceRange   string
+		side         models.Side
+		quantity     models.Decimal
+		price        models.Decimal
+		priceRange   models.Decimal
 		jsonResponse string
 	}
 	type Expect struct {
@@ -110,7 +108,7 @@ func TestCreateAnOrder(t *testing.T) {
 	}{
 		// test case 1
 		{
-			param:  Param{orderType: "limit", productID: 1, side: "sell", quantity: "0.01", price: "500.0", priceRange: "", jsonResponse: testutil.GetCreateAnOrderJsonResponse()},
+			param:  Param{orderType: "limit", productID: 1, side: "sell", quantity: models.MustDecimal("0.01"), price: models.MustDecimal("500.0"), jsonResponse: testutil.GetCreateAnOrderJsonResponse()},
 			expect: Expect{path: "/orders/", body: testutil.GetExpectedCreateAnOrderRequestBody(), method: "POST", a: testutil.GetExpectedCreateAnOrderModel()},
 		},
 		// test case 2
@@ -120,11 +118,10 @@ func TestCreateAnOrder(t *testing.T) {
 		ts := testutil.GenerateTestServer(t, c.expect.path, c.expect.method, c.expect.body, c.param.jsonResponse)
 		defer ts.Close()
 
-		client, _ := NewClient("apiTokenID", "secret", nil)
-		client.testServer = ts
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
-		r, err := client.CreateAnOrder(ctx, c.param.orderType, c.param.side, c.param.quantity, c.param.price, c.param.priceRange, c.param.productID)
+		r, err := client.CreateAnOrder(ctx, c.param.orderType, c.param.side, c.param.quantity, c.param.price, c.param.priceRange, c.param.productID, "")
 		if err != nil {
 			t.Errorf("Error. %+v", err)
 		}
@@ -161,8 +158,7 @@ func TestCancelAnOrder(t *testing.T) {
 		ts := testutil.GenerateTestServer(t, c.expect.path, c.expect.method, c.expect.body, c.param.jsonResponse)
 		defer ts.Close()
 
-		client, _ := NewClient("apiTokenID", "secret", nil)
-		client.testServer = ts
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		order, _ := client.CancelAnOrder(ctx, c.param.orderID)
@@ -175,8 +171,8 @@ func TestCancelAnOrder(t *testing.T) {
 func TestEditALiveOrder(t *testing.T) {
 	type Param struct {
 		orderID      int
-		quantity     string
-		price        string
+		quantity     models.Decimal
+		price        models.Decimal
 		jsonResponse string
 	}
 	type Expect struct {
@@ -191,7 +187,7 @@ func TestEditALiveOrder(t *testing.T) {
 	}{
 		// test case 1
 		{
-			param:  Param{orderID: 2157474, quantity: "0.02", price: "520.0", jsonResponse: testutil.GetEditALiveOrderJsonResponse()},
+			param:  Param{orderID: 2157474, quantity: models.MustDecimal("0.02"), price: models.MustDecimal("520.0"), jsonResponse: testutil.GetEditALiveOrderJsonResponse()},
 			expect: Expect{path: "/orders/2157474", method: "PUT", body: testutil.GetExpectedEditALiveOrderRequestBody(), order: testutil.GetExpectedEditALiveOrderModel()},
 		},
 		// test case 2
@@ -201,8 +197,7 @@ func TestEditALiveOrder(t *testing.T) {
 		ts := testutil.GenerateTestServer(t, c.expect.path, c.expect.method, c.expect.body, c.param.jsonResponse)
 		defer ts.Close()
 
-		client, _ := NewClient("apiTokenID", "secret", nil)
-		client.testServer = ts
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		order, _ := client.EditALiveOrder(ctx, c.param.orderID, c.param.quantity, c.param.price)
@@ -239,8 +234,7 @@ func TestGetAnOrderTrades(t *testing.T) {
 		ts := testutil.GenerateTestServer(t, c.expect.path, c.expect.method, c.expect.body, c.param.jsonResponse)
 		defer ts.Close()
 
-		client, _ := NewClient("apiTokenID", "secret", nil)
-		client.testServer = ts
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		trades, _ := client.GetAnOrderTrades(ctx, 1)
@@ -249,3 +243,171 @@ func TestGetAnOrderTrades(t *testing.T) {
 		}
 	}
 }
+
+func TestEditThenCancelAnOrder(t *testing.T) {
+	s := testutil.NewServer(t).InOrder()
+	defer s.Close()
+	s.Expect("PUT", "/orders/2157474").
+		WithJSONBody(testutil.GetExpectedEditALiveOrderRequestBody()).
+		WithJWT("secret", map[string]interface{}{"token_id": "apiTokenID"}).
+		Respond(200, testutil.GetEditALiveOrderJsonResponse())
+	s.Expect("PUT", "/orders/2157474/cancel").
+		WithHeader("X-Quoine-API-Version", "2").
+		Respond(200, testutil.GetCancelAnOrderJsonResponse())
+
+	client, _ := NewClient("apiTokenID", "secret", WithBaseURL(s.URL))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := client.EditALiveOrder(ctx, 2157474, models.MustDecimal("0.02"), models.MustDecimal("520.0")); err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	if _, err := client.CancelAnOrder(ctx, 2157474); err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	if err := s.Verify(); err != nil {
+		t.Errorf("Error. %+v", err)
+	}
+}
+
+func TestGetOrdersByFilter(t *testing.T) {
+	type Param struct {
+		filter OrderFilter
+	}
+	type Expect struct {
+		path string
+		err  bool
+	}
+	cases := []struct {
+		param  Param
+		expect Expect
+	}{
+		// test case 1: nothing is sent for zero fields
+		{param: Param{filter: OrderFilter{}}, expect: Expect{path: "/orders"}},
+		// test case 2
+		{
+			param: Param{filter: OrderFilter{ProductID: 1, WithDetails: true, FundingCurrency: "USD", Status: models.OrderStatusLive,
+				Side: models.SideBuy, TradingType: models.TradingTypeMargin, ClientOrderID: "bot-1",
+				CreatedFrom: time.Unix(1457370745, 0), CreatedTo: time.Unix(1457370800, 0), ListOptions: ListOptions{Page: 2, Limit: 50}}},
+			expect: Expect{path: "/orders?client_order_id=bot-1&created_at_gte=1457370745&created_at_lt=1457370800&funding_currency=USD&limit=50&page=2&product_id=1&side=buy&status=live&trading_type=margin&with_details=1"},
+		},
+		// test case 3
+		{param: Param{filter: OrderFilter{Side: "long"}}, expect: Expect{err: true}},
+		// test case 4
+		{param: Param{filter: OrderFilter{CreatedFrom: time.Unix(200, 0), CreatedTo: time.Unix(100, 0)}}, expect: Expect{err: true}},
+	}
+	for _, c := range cases {
+		s := testutil.NewServer(t)
+		if !c.expect.err {
+			s.Expect("GET", c.expect.path).Respond(200, testutil.GetOrdersJsonResponse())
+		}
+
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(s.URL))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		r, err := client.GetOrdersByFilter(ctx, c.param.filter)
+		cancel()
+		s.Close()
+		if (err != nil) != c.expect.err {
+			t.Errorf("Wrong error. %+v", err)
+		}
+		if !c.expect.err && !cmp.Equal(r, testutil.GetExpectedOrdersModel()) {
+			t.Errorf("Wrong attribute. %+v", cmp.Diff(r, testutil.GetExpectedOrdersModel()))
+		}
+		if err := s.Verify(); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestOrderByClientID(t *testing.T) {
+	order := `{"id":2157474,"client_order_id":"bot-1","status":"live"}`
+	orders := `{"models":[{"id":2157474,"client_order_id":"bot-1","status":"live"}],"current_page":1,"total_pages":1}`
+	notFound := `{"message":"not found"}`
+	type Param struct {
+		setup func(s *testutil.Server)
+		call  func(ctx context.Context, client *Client) (*models.Order, error)
+	}
+	type Expect struct {
+		id       int
+		notFound bool
+	}
+	get := func(ctx context.Context, client *Client) (*models.Order, error) {
+		return client.GetOrderByClientID(ctx, "bot-1")
+	}
+	cancelOrder := func(ctx context.Context, client *Client) (*models.Order, error) {
+		return client.CancelOrderByClientID(ctx, "bot-1")
+	}
+	edit := func(ctx context.Context, client *Client) (*models.Order, error) {
+		return client.EditOrderByClientID(ctx, "bot-1", &EditOrderRequest{Price: decimalPtr("520.0")})
+	}
+	cases := []struct {
+		param  Param
+		expect Expect
+	}{
+		// test case 1: the client-ID endpoint
+		{param: Param{setup: func(s *testutil.Server) {
+			s.Expect("GET", "/orders/client:bot-1").Respond(200, order)
+		}, call: get}, expect: Expect{id: 2157474}},
+		// test case 2: falls back to a filtered scan
+		{param: Param{setup: func(s *testutil.Server) {
+			s.Expect("GET", "/orders/client:bot-1").Respond(404, notFound)
+			s.Expect("GET", "/orders?client_order_id=bot-1&page=1").Respond(200, orders)
+		}, call: get}, expect: Expect{id: 2157474}},
+		// test case 3: the scan finds nothing either
+		{param: Param{setup: func(s *testutil.Server) {
+			s.Expect("GET", "/orders/client:bot-1").Respond(404, notFound)
+			s.Expect("GET", "/orders?client_order_id=bot-1&page=1").Respond(200, `{"models":[],"current_page":1,"total_pages":1}`)
+		}, call: get}, expect: Expect{notFound: true}},
+		// test case 4: the scan stops at the page holding the order
+		{param: Param{setup: func(s *testutil.Server) {
+			s.Expect("GET", "/orders/client:bot-1").Respond(404, notFound)
+			s.Expect("GET", "/orders?client_order_id=bot-1&page=1").Respond(200, `{"models":[{"id":1,"client_order_id":"bot-0"}],"current_page":1,"total_pages":3}`)
+			s.Expect("GET", "/orders?client_order_id=bot-1&page=2").Respond(200, `{"models":[{"id":2157474,"client_order_id":"bot-1","status":"live"}],"current_page":2,"total_pages":3}`)
+		}, call: get}, expect: Expect{id: 2157474}},
+		// test case 5
+		{param: Param{setup: func(s *testutil.Server) {
+			s.Expect("PUT", "/orders/client:bot-1/cancel").Respond(200, order)
+		}, call: cancelOrder}, expect: Expect{id: 2157474}},
+		// test case 6
+		{param: Param{setup: func(s *testutil.Server) {
+			s.Expect("PUT", "/orders/client:bot-1/cancel").Respond(404, notFound)
+			s.Expect("GET", "/orders?client_order_id=bot-1&page=1").Respond(200, orders)
+			s.Expect("PUT", "/orders/2157474/cancel").Respond(200, order)
+		}, call: cancelOrder}, expect: Expect{id: 2157474}},
+		// test case 7
+		{param: Param{setup: func(s *testutil.Server) {
+			s.Expect("PUT", "/orders/client:bot-1").WithJSONBody(`{"order":{"price":"520.0"}}`).Respond(200, order)
+		}, call: edit}, expect: Expect{id: 2157474}},
+		// test case 8
+		{param: Param{setup: func(s *testutil.Server) {
+			s.Expect("PUT", "/orders/client:bot-1").Respond(404, notFound)
+			s.Expect("GET", "/orders?client_order_id=bot-1&page=1").Respond(200, orders)
+			s.Expect("PUT", "/orders/2157474").WithJSONBody(`{"order":{"price":"520.0"}}`).Respond(200, order)
+		}, call: edit}, expect: Expect{id: 2157474}},
+	}
+	for i, c := range cases {
+		s := testutil.NewServer(t).InOrder()
+		c.param.setup(s)
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(s.URL))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		o, err := c.param.call(ctx, client)
+		cancel()
+		if c.expect.notFound {
+			if !IsNotFound(err) {
+				t.Errorf("Wrong error in case %d. %+v", i+1, err)
+			}
+		} else if err != nil {
+			t.Errorf("Error in case %d. %+v", i+1, err)
+		} else if o.ID != c.expect.id {
+			t.Errorf("Wrong order in case %d. %+v", i+1, o)
+		}
+		if err := s.Verify(); err != nil {
+			t.Errorf("Error in case %d. %+v", i+1, err)
+		}
+		s.Close()
+	}
+
+	client, _ := NewClient("apiTokenID", "secret")
+	if _, err := client.GetOrderByClientID(context.Background(), ""); err == nil {
+		t.Errorf("Wrong error for an empty client order id")
+	}
+}
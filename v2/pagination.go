@@ -0,0 +1,177 @@
+package quoinex
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// ListOptions controls how an iterator walks a paginated endpoint.
+type ListOptions struct {
+	// Page is the first page to fetch. Zero starts at page 1.
+	Page int
+	// Limit is the page size sent to the server. Zero uses the server default.
+	Limit int
+	// MaxItems stops the iterator after this many items. Zero means no limit.
+	MaxItems int
+}
+
+func (o ListOptions) setQuery(q map[string]string, page int) {
+	q["page"] = strconv.Itoa(page)
+	if o.Limit > 0 {
+		q["limit"] = strconv.Itoa(o.Limit)
+	}
+}
+
+// setPageQuery sets the page and limit of a single-page request, sending
+// each only when set.
+func (o ListOptions) setPageQuery(q map[string]string) {
+	if o.Page > 0 {
+		q["page"] = strconv.Itoa(o.Page)
+	}
+	if o.Limit > 0 {
+		q["limit"] = strconv.Itoa(o.Limit)
+	}
+}
+
+// PageError is returned by an iterator when fetching a page fails.
+type PageError struct {
+	Page int
+	Err  error
+}
+
+func (e *PageError) Error() string {
+	return fmt.Sprintf("page %d: %v", e.Page, e.Err)
+}
+
+func (e *PageError) Unwrap() error {
+	return e.Err
+}
+
+// Iterator walks the items of a paginated endpoint, one page at a time.
+// No request is sent until Next is called.
+type Iterator[T any] struct {
+	pager
+	items []T
+	cur   T
+}
+
+// NewIterator returns an iterator that loads each page with fetch, which
+// returns the items on the page and the total number of pages.
+func NewIterator[T any](ctx context.Context, opts ListOptions, fetch func(ctx context.Context, page int) ([]T, int, error)) *Iterator[T] {
+	it := &Iterator[T]{}
+	it.pager = pager{ctx: ctx, opts: opts, fetch: func(ctx context.Context, page int) (int, int, error) {
+		items, totalPages, err := fetch(ctx, page)
+		if err != nil {
+			return 0, 0, err
+		}
+		it.items = items
+		return len(items), totalPages, nil
+	}}
+	return it
+}
+
+// newPageIterator is NewIterator over an endpoint returning *L, with split
+// taking the items and the total number of pages out of a page.
+func newPageIterator[T, L any](ctx context.Context, opts ListOptions, fetch func(ctx context.Context, page int) (*L, error), split func(*L) ([]T, int)) *Iterator[T] {
+	return NewIterator(ctx, opts, func(ctx context.Context, page int) ([]T, int, error) {
+		l, err := fetch(ctx, page)
+		if err != nil || l == nil {
+			return nil, 0, err
+		}
+		items, totalPages := split(l)
+		return items, totalPages, nil
+	})
+}
+
+// Next advances to the next item. It returns false when there are no more
+// items or an error occurred; check Err afterwards.
+func (it *Iterator[T]) Next() bool {
+	var zero T
+	i, ok := it.next()
+	it.cur = zero
+	if ok {
+		it.cur = it.items[i]
+	}
+	return ok
+}
+
+// Item returns the current item.
+func (it *Iterator[T]) Item() T {
+	return it.cur
+}
+
+// All drains the iterator. On error it returns the items read so far.
+func (it *Iterator[T]) All() ([]T, error) {
+	var all []T
+	for it.Next() {
+		all = append(all, it.Item())
+	}
+	return all, it.Err()
+}
+
+// pager walks pages lazily for Iterator. fetch loads one page into the
+// iterator and returns the number of items on it and the total number of
+// pages reported by the server.
+type pager struct {
+	ctx   context.Context
+	opts  ListOptions
+	fetch func(ctx context.Context, page int) (n, totalPages int, err error)
+
+	page  int // last fetched page, 0 before the first fetch
+	total int
+	n     int // items on the current page
+	i     int // index of the current item
+	seen  int
+	done  bool
+	err   error
+}
+
+// next advances to the next item and returns its index on the current page.
+func (p *pager) next() (int, bool) {
+	if p.done {
+		return 0, false
+	}
+	if err := p.ctx.Err(); err != nil {
+		return p.stop(err)
+	}
+	if p.opts.MaxItems > 0 && p.seen >= p.opts.MaxItems {
+		return p.stop(nil)
+	}
+
+	p.i++
+	for p.i >= p.n {
+		page := p.page + 1
+		if p.page == 0 && p.opts.Page > 1 {
+			page = p.opts.Page
+		}
+		if p.page > 0 && page > p.total {
+			return p.stop(nil)
+		}
+		n, total, err := p.fetch(p.ctx, page)
+		if err != nil {
+			return p.stop(&PageError{Page: page, Err: err})
+		}
+		p.page, p.total, p.n, p.i = page, total, n, 0
+		if n == 0 {
+			return p.stop(nil)
+		}
+	}
+	p.seen++
+	return p.i, true
+}
+
+func (p *pager) stop(err error) (int, bool) {
+	p.done, p.err = true, err
+	return 0, false
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (p *pager) Err() error {
+	return p.err
+}
+
+// Page returns the number of the page the current item came from.
+func (p *pager) Page() int {
+	return p.page
+}
@@ -0,0 +1,35 @@
+//go:build go1.23
+// +build go1.23
+
+package quoinex
+
+import (
+	"github.com/sho3imo/quoinex-go-client/v2/models"
+	"iter"
+)
+
+// seq adapts a Next/current/Err iterator to a range-over-func sequence. A
+// failed page is yielded once as a nil item with its error.
+func seq[T any](next func() bool, cur func() T, errf func() error) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for next() {
+			if !yield(cur(), nil) {
+				return
+			}
+		}
+		if err := errf(); err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// Seq returns the remaining items as an iter.Seq2.
+func (it *Iterator[T]) Seq() iter.Seq2[T, error] {
+	return seq(it.Next, it.Item, it.Err)
+}
+
+// Seq returns the remaining executions as an iter.Seq2.
+func (it *ExecutionHistoryIterator) Seq() iter.Seq2[*models.ExecutionsModels, error] {
+	return seq(it.Next, it.Execution, it.Err)
+}
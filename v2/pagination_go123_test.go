@@ -0,0 +1,45 @@
+//go:build go1.23
+// +build go1.23
+
+package quoinex
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestIteratorSeq(t *testing.T) {
+	ts, _ := pagedServer(t, 3, 2, map[int]bool{3: true})
+	defer ts.Close()
+
+	client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL), WithRetryPolicy(NoRetryPolicy))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var ids []int
+	var pageErr *PageError
+	for order, err := range client.Orders(ctx, OrderFilter{}).Seq() {
+		if err != nil {
+			if !errors.As(err, &pageErr) || pageErr.Page != 3 {
+				t.Errorf("Wrong error. %+v", err)
+			}
+			break
+		}
+		ids = append(ids, order.ID)
+	}
+	if len(ids) != 4 || pageErr == nil {
+		t.Errorf("Wrong iteration. ids: %v, err: %v", ids, pageErr)
+	}
+
+	// breaking out early must not fetch further pages
+	count := 0
+	for range client.Loans(ctx, LoanFilter{}).Seq() {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("Wrong count. %d", count)
+	}
+}
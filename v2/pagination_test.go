@@ -0,0 +1,230 @@
+package quoinex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/go-cmp/cmp"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// pagedServer serves totalPages pages of perPage {"id": n} models on any
+// path, numbering ids from 1. Pages listed in fail answer 500. It returns
+// the request URIs it saw.
+func pagedServer(t *testing.T, totalPages, perPage int, fail map[int]bool) (*httptest.Server, func() []string) {
+	var mu sync.Mutex
+	var seen []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen = append(seen, r.URL.RequestURI())
+		mu.Unlock()
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if fail[page] {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		var ids []string
+		if page >= 1 && page <= totalPages {
+			for i := 1; i <= perPage; i++ {
+				ids = append(ids, fmt.Sprintf(`{"id":%d}`, (page-1)*perPage+i))
+			}
+		}
+		w.Header().Set("content-Type", "application/json")
+		fmt.Fprintf(w, `{"models":[%s],"current_page":%d,"total_pages":%d}`, strings.Join(ids, ","), page, totalPages)
+	}))
+	return ts, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), seen...)
+	}
+}
+
+func TestOrdersIterator(t *testing.T) {
+	type Param struct {
+		filter OrderFilter
+		fail   map[int]bool
+	}
+	type Expect struct {
+		ids      []int
+		requests []string
+		errPage  int
+	}
+	cases := []struct {
+		param  Param
+		expect Expect
+	}{
+		// test case 1: walks every page
+		{
+			param: Param{filter: OrderFilter{ProductID: 1, Status: "live", ListOptions: ListOptions{Limit: 2}}},
+			expect: Expect{ids: []int{1, 2, 3, 4, 5, 6}, requests: []string{
+				"/orders?limit=2&page=1&product_id=1&status=live",
+				"/orders?limit=2&page=2&product_id=1&status=live",
+				"/orders?limit=2&page=3&product_id=1&status=live"}},
+		},
+		// test case 2: MaxItems stops before the last page
+		{
+			param: Param{filter: OrderFilter{ListOptions: ListOptions{MaxItems: 3}}},
+			expect: Expect{ids: []int{1, 2, 3}, requests: []string{
+				"/orders?page=1",
+				"/orders?page=2"}},
+		},
+		// test case 3: starts from a later page
+		{
+			param: Param{filter: OrderFilter{WithDetails: true, ListOptions: ListOptions{Page: 3}}},
+			expect: Expect{ids: []int{5, 6}, requests: []string{
+				"/orders?page=3&with_details=1"}},
+		},
+		// test case 4: a failed page stops the walk and reports its number
+		{
+			param: Param{filter: OrderFilter{}, fail: map[int]bool{2: true}},
+			expect: Expect{ids: []int{1, 2}, errPage: 2, requests: []string{
+				"/orders?page=1",
+				"/orders?page=2"}},
+		},
+	}
+	for _, c := range cases {
+		ts, requests := pagedServer(t, 3, 2, c.param.fail)
+		defer ts.Close()
+
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL), WithRetryPolicy(NoRetryPolicy))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		orders, err := client.Orders(ctx, c.param.filter).All()
+
+		var ids []int
+		for _, o := range orders {
+			ids = append(ids, o.ID)
+		}
+		if !cmp.Equal(ids, c.expect.ids) {
+			t.Errorf("Wrong ids. %+v", cmp.Diff(ids, c.expect.ids))
+		}
+		if !cmp.Equal(requests(), c.expect.requests) {
+			t.Errorf("Wrong requests. %+v", cmp.Diff(requests(), c.expect.requests))
+		}
+		var pageErr *PageError
+		var apiErr *APIError
+		if c.expect.errPage == 0 && err != nil {
+			t.Errorf("Error. %+v", err)
+		}
+		if c.expect.errPage != 0 && (!errors.As(err, &pageErr) || pageErr.Page != c.expect.errPage || !errors.As(err, &apiErr) || apiErr.StatusCode != 500) {
+			t.Errorf("Wrong error. %+v", err)
+		}
+	}
+}
+
+func TestIteratorStopsOnCancel(t *testing.T) {
+	ts, requests := pagedServer(t, 3, 2, nil)
+	defer ts.Close()
+
+	client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
+	ctx, cancel := context.WithCancel(context.Background())
+	it := client.Trades(ctx, TradeFilter{FundingCurrency: "USD"})
+	if !it.Next() || it.Item().ID != 1 || it.Page() != 1 {
+		t.Fatalf("Wrong first trade. %+v", it.Err())
+	}
+	cancel()
+	if it.Next() {
+		t.Errorf("Next must stop after cancel")
+	}
+	if !errors.Is(it.Err(), context.Canceled) {
+		t.Errorf("Wrong error. %+v", it.Err())
+	}
+	if len(requests()) != 1 {
+		t.Errorf("Wrong requests. %+v", requests())
+	}
+}
+
+func TestIteratorsWalkEveryPage(t *testing.T) {
+	type Param struct {
+		all func(c *Client, ctx context.Context) (int, error)
+	}
+	type Expect struct {
+		count int
+		path  string
+	}
+	cases := []struct {
+		param  Param
+		expect Expect
+	}{
+		// test case 1
+		{
+			param: Param{all: func(c *Client, ctx context.Context) (int, error) {
+				v, err := c.Trades(ctx, TradeFilter{Status: "open"}).All()
+				return len(v), err
+			}},
+			expect: Expect{count: 4, path: "/trades?page=2&status=open"},
+		},
+		// test case 2
+		{
+			param: Param{all: func(c *Client, ctx context.Context) (int, error) {
+				v, err := c.Loans(ctx, LoanFilter{Currency: "JPY"}).All()
+				return len(v), err
+			}},
+			expect: Expect{count: 4, path: "/loans?currency=JPY&page=2"},
+		},
+		// test case 3
+		{
+			param: Param{all: func(c *Client, ctx context.Context) (int, error) {
+				v, err := c.LoanBids(ctx, LoanBidFilter{Currency: "USD"}).All()
+				return len(v), err
+			}},
+			expect: Expect{count: 4, path: "/loan_bids?currency=USD&page=2"},
+		},
+		// test case 4
+		{
+			param: Param{all: func(c *Client, ctx context.Context) (int, error) {
+				v, err := c.Executions(ctx, ExecutionFilter{ProductID: 1, ListOptions: ListOptions{Limit: 2}}).All()
+				return len(v), err
+			}},
+			expect: Expect{count: 4, path: "/executions?limit=2&page=2&product_id=1"},
+		},
+		// test case 5
+		{
+			param: Param{all: func(c *Client, ctx context.Context) (int, error) {
+				v, err := c.OwnExecutions(ctx, ExecutionFilter{ProductID: 1}).All()
+				return len(v), err
+			}},
+			expect: Expect{count: 4, path: "/executions/me?page=2&product_id=1"},
+		},
+	}
+	for _, c := range cases {
+		ts, requests := pagedServer(t, 2, 2, nil)
+		defer ts.Close()
+
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		count, err := c.param.all(client, ctx)
+		if err != nil {
+			t.Errorf("Error. %+v", err)
+		}
+		if count != c.expect.count {
+			t.Errorf("Wrong count. actual: %d, expect: %d", count, c.expect.count)
+		}
+		seen := requests()
+		if len(seen) != 2 || seen[1] != c.expect.path {
+			t.Errorf("Wrong requests. %+v", seen)
+		}
+	}
+}
+
+func TestNewIterator(t *testing.T) {
+	pages := [][]string{{"a", "b"}, {"c"}}
+	it := NewIterator(context.Background(), ListOptions{}, func(ctx context.Context, page int) ([]string, int, error) {
+		return pages[page-1], len(pages), nil
+	})
+	all, err := it.All()
+	if err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	if diff := cmp.Diff([]string{"a", "b", "c"}, all); diff != "" {
+		t.Errorf("Wrong items. %s", diff)
+	}
+}
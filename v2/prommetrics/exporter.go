@@ -0,0 +1,202 @@
+// Package prommetrics exports quoinex client metrics in the Prometheus text
+// exposition format without depending on the Prometheus client library.
+package prommetrics
+
+import (
+	"context"
+	"fmt"
+	"github.com/sho3imo/quoinex-go-client/v2"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBuckets are the request latency histogram bounds in seconds.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+type requestKey struct {
+	operation, method, status, errorClass string
+}
+
+type histogram struct {
+	counts []uint64 // per bucket, not cumulative
+	sum    float64
+	count  uint64
+}
+
+type waitStats struct {
+	seconds float64
+	count   uint64
+}
+
+// Exporter implements quoinex.Metrics and serves what it collected as
+// Prometheus text on ServeHTTP. Pass it to quoinex.WithMetrics.
+type Exporter struct {
+	buckets []float64
+
+	mu        sync.Mutex
+	requests  map[requestKey]uint64
+	durations map[string]*histogram
+	waits     map[string]*waitStats
+}
+
+var _ quoinex.Metrics = (*Exporter)(nil)
+
+// New returns an Exporter using buckets for the latency histogram, or
+// DefaultBuckets when none are given.
+func New(buckets ...float64) *Exporter {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	b := append([]float64(nil), buckets...)
+	sort.Float64s(b)
+	return &Exporter{
+		buckets:   b,
+		requests:  map[requestKey]uint64{},
+		durations: map[string]*histogram{},
+		waits:     map[string]*waitStats{},
+	}
+}
+
+func (e *Exporter) ObserveRequest(ctx context.Context, m quoinex.RequestMetric) {
+	status := ""
+	if m.StatusCode != 0 {
+		status = strconv.Itoa(m.StatusCode)
+	}
+	seconds := m.Latency.Seconds()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.requests[requestKey{m.Operation, m.Method, status, m.ErrorClass}]++
+	h, ok := e.durations[m.Operation]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(e.buckets))}
+		e.durations[m.Operation] = h
+	}
+	for i, bound := range e.buckets {
+		if seconds <= bound {
+			h.counts[i]++
+			break
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+func (e *Exporter) ObserveRateLimitWait(ctx context.Context, operation string, wait time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	w, ok := e.waits[operation]
+	if !ok {
+		w = &waitStats{}
+		e.waits[operation] = w
+	}
+	w.seconds += wait.Seconds()
+	w.count++
+}
+
+// ServeHTTP writes the collected metrics in the text exposition format.
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	e.WriteTo(w)
+}
+
+// WriteTo writes the collected metrics in the text exposition format.
+func (e *Exporter) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+	e.mu.Lock()
+	e.writeRequests(&b)
+	e.writeDurations(&b)
+	e.writeWaits(&b)
+	e.mu.Unlock()
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+func (e *Exporter) writeRequests(b *strings.Builder) {
+	keys := make([]requestKey, 0, len(e.requests))
+	for k := range e.requests {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, c := keys[i], keys[j]
+		if a.operation != c.operation {
+			return a.operation < c.operation
+		}
+		if a.method != c.method {
+			return a.method < c.method
+		}
+		if a.status != c.status {
+			return a.status < c.status
+		}
+		return a.errorClass < c.errorClass
+	})
+
+	b.WriteString("# HELP quoinex_requests_total HTTP requests sent to the exchange, retries included.\n")
+	b.WriteString("# TYPE quoinex_requests_total counter\n")
+	for _, k := range keys {
+		fmt.Fprintf(b, "quoinex_requests_total{operation=%s,method=%s,status=%s,error_class=%s} %d\n",
+			quote(k.operation), quote(k.method), quote(k.status), quote(k.errorClass), e.requests[k])
+	}
+}
+
+func (e *Exporter) writeDurations(b *strings.Builder) {
+	b.WriteString("# HELP quoinex_request_duration_seconds Latency of HTTP requests to the exchange.\n")
+	b.WriteString("# TYPE quoinex_request_duration_seconds histogram\n")
+	for _, op := range sortedKeys(e.durations) {
+		h := e.durations[op]
+		var cumulative uint64
+		for i, bound := range e.buckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(b, "quoinex_request_duration_seconds_bucket{operation=%s,le=%s} %d\n",
+				quote(op), quote(strconv.FormatFloat(bound, 'g', -1, 64)), cumulative)
+		}
+		fmt.Fprintf(b, "quoinex_request_duration_seconds_bucket{operation=%s,le=\"+Inf\"} %d\n", quote(op), h.count)
+		fmt.Fprintf(b, "quoinex_request_duration_seconds_sum{operation=%s} %s\n", quote(op), formatFloat(h.sum))
+		fmt.Fprintf(b, "quoinex_request_duration_seconds_count{operation=%s} %d\n", quote(op), h.count)
+	}
+}
+
+func (e *Exporter) writeWaits(b *strings.Builder) {
+	ops := make([]string, 0, len(e.waits))
+	for op := range e.waits {
+		ops = append(ops, op)
+	}
+	sort.Strings(ops)
+
+	b.WriteString("# HELP quoinex_rate_limit_wait_seconds_total Time requests were held back by the client rate limiter.\n")
+	b.WriteString("# TYPE quoinex_rate_limit_wait_seconds_total counter\n")
+	for _, op := range ops {
+		fmt.Fprintf(b, "quoinex_rate_limit_wait_seconds_total{operation=%s} %s\n", quote(op), formatFloat(e.waits[op].seconds))
+	}
+	b.WriteString("# HELP quoinex_rate_limit_waits_total Requests that went through the client rate limiter.\n")
+	b.WriteString("# TYPE quoinex_rate_limit_waits_total counter\n")
+	for _, op := range ops {
+		fmt.Fprintf(b, "quoinex_rate_limit_waits_total{operation=%s} %d\n", quote(op), e.waits[op].count)
+	}
+}
+
+func sortedKeys(m map[string]*histogram) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// quote escapes a label value as the exposition format requires.
+func quote(s string) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	s = strings.Replace(s, "\n", `\n`, -1)
+	s = strings.Replace(s, `"`, `\"`, -1)
+	return `"` + s + `"`
+}
@@ -0,0 +1,121 @@
+package prommetrics
+
+import (
+	"context"
+	"github.com/sho3imo/quoinex-go-client/v2"
+	"github.com/sho3imo/quoinex-go-client/v2/testutil"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExporter(t *testing.T) {
+	type Param struct {
+		call func(c *quoinex.Client, ctx context.Context) error
+		ts   func(t *testing.T) *httptest.Server
+	}
+	type Expect struct {
+		lines []string
+	}
+	cases := []struct {
+		param  Param
+		expect Expect
+	}{
+		// test case 1
+		{
+			param: Param{
+				ts: func(t *testing.T) *httptest.Server {
+					return testutil.GenerateTestServer(t, "/products/1", "GET", "", testutil.GetProductJsonResponse())
+				},
+				call: func(c *quoinex.Client, ctx context.Context) error {
+					_, err := c.GetProduct(ctx, 1)
+					return err
+				}},
+			expect: Expect{lines: []string{
+				`quoinex_requests_total{operation="GetProduct",method="GET",status="200",error_class=""} 1`,
+				`quoinex_request_duration_seconds_bucket{operation="GetProduct",le="+Inf"} 1`,
+				`quoinex_request_duration_seconds_count{operation="GetProduct"} 1`,
+				`quoinex_rate_limit_waits_total{operation="GetProduct"} 1`,
+			}},
+		},
+		// test case 2: every attempt of a retried request is counted
+		{
+			param: Param{
+				ts: func(t *testing.T) *httptest.Server {
+					return testutil.GenerateErrorTestServer(t, "/orders/1", "GET", 503, `{"message":"unavailable"}`)
+				},
+				call: func(c *quoinex.Client, ctx context.Context) error {
+					_, err := c.GetAnOrder(ctx, 1)
+					return err
+				}},
+			expect: Expect{lines: []string{
+				`quoinex_requests_total{operation="GetAnOrder",method="GET",status="503",error_class="server_error"} 2`,
+				`quoinex_request_duration_seconds_count{operation="GetAnOrder"} 2`,
+				`quoinex_rate_limit_waits_total{operation="GetAnOrder"} 2`,
+			}},
+		},
+		// test case 3
+		{
+			param: Param{
+				ts: func(t *testing.T) *httptest.Server {
+					return testutil.GenerateErrorTestServer(t, "/orders/1/cancel", "PUT", 404, `{"message":"not found"}`)
+				},
+				call: func(c *quoinex.Client, ctx context.Context) error {
+					_, err := c.CancelAnOrder(ctx, 1)
+					return err
+				}},
+			expect: Expect{lines: []string{
+				`quoinex_requests_total{operation="CancelAnOrder",method="PUT",status="404",error_class="client_error"} 1`,
+			}},
+		},
+	}
+	for _, c := range cases {
+		ts := c.param.ts(t)
+		defer ts.Close()
+
+		exporter := New()
+		client, _ := quoinex.NewClient("apiTokenID", "secret", quoinex.WithBaseURL(ts.URL), quoinex.WithMetrics(exporter),
+			quoinex.WithRetryPolicy(quoinex.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		c.param.call(client, ctx)
+
+		rec := httptest.NewRecorder()
+		exporter.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+		if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+			t.Errorf("Wrong content type. %s", ct)
+		}
+		body, _ := ioutil.ReadAll(rec.Body)
+		for _, line := range c.expect.lines {
+			if !strings.Contains(string(body), line+"\n") {
+				t.Errorf("Wrong metrics. missing %s in\n%s", line, body)
+			}
+		}
+	}
+}
+
+func TestExporterRequestOrder(t *testing.T) {
+	exporter := New()
+	for _, method := range []string{"PUT", "GET", "POST"} {
+		exporter.ObserveRequest(context.Background(), quoinex.RequestMetric{Operation: "Do", Method: method, StatusCode: 200})
+	}
+	want := `quoinex_requests_total{operation="Do",method="GET",status="200",error_class=""} 1
+quoinex_requests_total{operation="Do",method="POST",status="200",error_class=""} 1
+quoinex_requests_total{operation="Do",method="PUT",status="200",error_class=""} 1
+`
+	for i := 0; i < 10; i++ {
+		var b strings.Builder
+		exporter.WriteTo(&b)
+		if !strings.Contains(b.String(), want) {
+			t.Fatalf("Wrong request order.\n%s", b.String())
+		}
+	}
+}
+
+func TestQuote(t *testing.T) {
+	if got := quote("a\"b\\c\nd"); got != `"a\"b\\c\nd"` {
+		t.Errorf("Wrong quote. %s", got)
+	}
+}
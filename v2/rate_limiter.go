@@ -0,0 +1,119 @@
+package quoinex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// Liquid allows 300 requests per 5 minutes per API token.
+	defaultRateLimitRequests int           = 300
+	defaultRateLimitPeriod   time.Duration = 5 * time.Minute
+)
+
+var RateLimitDeadlineError = errors.New("rate limit wait exceeds context deadline")
+
+// RateLimiter is a token bucket holding up to `requests` tokens and
+// refilling them evenly over `per`.
+type RateLimiter struct {
+	mu       sync.Mutex
+	capacity float64
+	tokens   float64
+	interval time.Duration
+	last     time.Time
+	now      func() time.Time
+}
+
+// RateLimitBudget is a snapshot of the limiter state.
+type RateLimitBudget struct {
+	Capacity  int
+	Remaining int
+	// NextToken is how long until another token becomes available.
+	NextToken time.Duration
+}
+
+// NewRateLimiter allows requests requests per per. It panics if either is
+// not positive, like time.NewTicker.
+func NewRateLimiter(requests int, per time.Duration) *RateLimiter {
+	if requests <= 0 || per <= 0 {
+		panic(fmt.Sprintf("quoinex: invalid rate limit %d per %s", requests, per))
+	}
+	return &RateLimiter{
+		capacity: float64(requests),
+		tokens:   float64(requests),
+		interval: per / time.Duration(requests),
+		last:     time.Now(),
+		now:      time.Now,
+	}
+}
+
+// Wait blocks until a token is available or ctx is done. It fails fast
+// with RateLimitDeadlineError when the wait would outlive ctx's deadline.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		l.refill()
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+		wait := l.untilNextToken()
+		l.mu.Unlock()
+
+		if deadline, ok := ctx.Deadline(); ok && deadline.Sub(l.now()) < wait {
+			return RateLimitDeadlineError
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (l *RateLimiter) Budget() RateLimitBudget {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill()
+	return RateLimitBudget{
+		Capacity:  int(l.capacity),
+		Remaining: int(l.tokens),
+		NextToken: l.untilNextToken(),
+	}
+}
+
+func (l *RateLimiter) refill() {
+	now := l.now()
+	elapsed := now.Sub(l.last)
+	l.last = now
+	if elapsed <= 0 || l.interval <= 0 {
+		return
+	}
+	l.tokens += float64(elapsed) / float64(l.interval)
+	if l.tokens > l.capacity {
+		l.tokens = l.capacity
+	}
+}
+
+func (l *RateLimiter) untilNextToken() time.Duration {
+	if l.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - l.tokens) * float64(l.interval))
+}
+
+// RateLimitBudget reports the remaining request budget, or the zero value
+// when the client has no limiter.
+func (c *Client) RateLimitBudget() RateLimitBudget {
+	if c.RateLimiter == nil {
+		return RateLimitBudget{}
+	}
+	return c.RateLimiter.Budget()
+}
@@ -0,0 +1,125 @@
+package quoinex
+
+import (
+	"context"
+	"github.com/sho3imo/quoinex-go-client/v2/testutil"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterWait(t *testing.T) {
+	type Param struct {
+		requests int
+		per      time.Duration
+		calls    int
+		timeout  time.Duration
+	}
+	type Expect struct {
+		err       error
+		remaining int
+	}
+	cases := []struct {
+		param  Param
+		expect Expect
+	}{
+		// test case 1: within the burst
+		{
+			param:  Param{requests: 3, per: time.Second, calls: 2, timeout: time.Second},
+			expect: Expect{err: nil, remaining: 1},
+		},
+		// test case 2: waits for a refill
+		{
+			param:  Param{requests: 2, per: 100 * time.Millisecond, calls: 3, timeout: time.Second},
+			expect: Expect{err: nil, remaining: 0},
+		},
+		// test case 3: refill would outlive the deadline
+		{
+			param:  Param{requests: 1, per: time.Minute, calls: 2, timeout: 50 * time.Millisecond},
+			expect: Expect{err: RateLimitDeadlineError, remaining: 0},
+		},
+	}
+	for _, c := range cases {
+		limiter := NewRateLimiter(c.param.requests, c.param.per)
+		ctx, cancel := context.WithTimeout(context.Background(), c.param.timeout)
+		defer cancel()
+
+		var err error
+		for i := 0; i < c.param.calls && err == nil; i++ {
+			err = limiter.Wait(ctx)
+		}
+		if err != c.expect.err {
+			t.Errorf("Wrong err. actual: %+v, expect: %+v", err, c.expect.err)
+		}
+		if b := limiter.Budget(); b.Remaining != c.expect.remaining || b.Capacity != c.param.requests {
+			t.Errorf("Wrong budget. actual: %+v, case: %+v", b, c)
+		}
+	}
+}
+
+func TestNewRateLimiterPanics(t *testing.T) {
+	type Param struct {
+		requests int
+		per      time.Duration
+	}
+	cases := []Param{
+		// test case 1: a zero period would make Wait spin
+		{requests: 10, per: 0},
+		// test case 2
+		{requests: 10, per: -time.Second},
+		// test case 3
+		{requests: 0, per: time.Second},
+		// test case 4
+		{requests: -1, per: time.Second},
+	}
+	for i, c := range cases {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewRateLimiter must panic in case %d", i+1)
+				}
+			}()
+			NewRateLimiter(c.requests, c.per)
+		}()
+	}
+}
+
+func TestRateLimiterBudget(t *testing.T) {
+	now := time.Unix(1500000000, 0)
+	limiter := NewRateLimiter(10, 10*time.Second)
+	limiter.now = func() time.Time { return now }
+	limiter.last = now
+
+	for i := 0; i < 10; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("Error. %+v", err)
+		}
+	}
+	if b := limiter.Budget(); b.Remaining != 0 || b.NextToken != time.Second {
+		t.Errorf("Wrong budget. %+v", b)
+	}
+
+	now = now.Add(2500 * time.Millisecond)
+	if b := limiter.Budget(); b.Remaining != 2 || b.NextToken != 0 {
+		t.Errorf("Wrong budget. %+v", b)
+	}
+}
+
+func TestClientRateLimiter(t *testing.T) {
+	ts := testutil.GenerateTestServer(t, "/products/1", "GET", "", testutil.GetProductJsonResponse())
+	defer ts.Close()
+
+	client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
+	client.RateLimiter = NewRateLimiter(1, time.Minute)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := client.GetProduct(ctx, 1); err != nil {
+		t.Errorf("Error. %+v", err)
+	}
+	if b := client.RateLimitBudget(); b.Remaining != 0 {
+		t.Errorf("Wrong budget. %+v", b)
+	}
+	if _, err := client.GetProduct(ctx, 1); err != RateLimitDeadlineError {
+		t.Errorf("Wrong err. %+v", err)
+	}
+}
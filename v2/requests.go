@@ -0,0 +1,245 @@
+package quoinex
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/sho3imo/quoinex-go-client/v2/models"
+	"io"
+)
+
+// CreateOrderRequest is the body of POST /orders/. Optional fields left nil
+// or empty are omitted.
+//
+// Price is the limit price of limit orders and the trigger price of stop
+// orders. A limit order with DiscQuantity is an iceberg order showing that
+// much of Quantity at a time. Margin, CFD and perpetual orders set
+// TradingType and LeverageLevel; the fields after them apply only there.
+type CreateOrderRequest struct {
+	OrderType         models.OrderType        `json:"order_type"`
+	ProductID         int                     `json:"product_id"`
+	Side              models.Side             `json:"side"`
+	Quantity          models.Decimal          `json:"quantity"`
+	Price             *models.Decimal         `json:"price,omitempty"`
+	PriceRange        *models.Decimal         `json:"price_range,omitempty"`
+	ClientOrderID     string                  `json:"client_order_id,omitempty"`
+	DiscQuantity      *models.Decimal         `json:"disc_quantity,omitempty"`
+	TrailingStopType  models.TrailingStopType `json:"trailing_stop_type,omitempty"`
+	TrailingStopValue *models.Decimal         `json:"trailing_stop_value,omitempty"`
+	TradingType       models.TradingType      `json:"trading_type,omitempty"`
+	LeverageLevel     int                     `json:"leverage_level,omitempty"`
+	FundingCurrency   string                  `json:"funding_currency,omitempty"`
+	OrderDirection    models.OrderDirection   `json:"order_direction,omitempty"`
+	MarginType        models.MarginType       `json:"margin_type,omitempty"`
+	TakeProfit        *models.Decimal         `json:"take_profit,omitempty"`
+	StopLoss          *models.Decimal         `json:"stop_loss,omitempty"`
+}
+
+func (r *CreateOrderRequest) validate() error {
+	if r.OrderType == "" || r.Side == "" {
+		return fmt.Errorf("order_type and side are required")
+	}
+	for _, v := range []interface{ Validate() error }{r.OrderType, r.Side, r.TrailingStopType, r.TradingType, r.OrderDirection, r.MarginType} {
+		if err := v.Validate(); err != nil {
+			return err
+		}
+	}
+	if r.Quantity.Sign() <= 0 {
+		return fmt.Errorf("quantity must be positive")
+	}
+
+	switch r.OrderType {
+	case models.OrderTypeLimit, models.OrderTypeLimitPostOnly, models.OrderTypeStop:
+		if !positive(r.Price) {
+			return fmt.Errorf("%s order requires a positive price", r.OrderType)
+		}
+	case models.OrderTypeMarketWithRange:
+		if !positive(r.PriceRange) {
+			return fmt.Errorf("market_with_range order requires a positive price_range")
+		}
+	case models.OrderTypeTrailingStop:
+		if r.TrailingStopType == "" || !positive(r.TrailingStopValue) {
+			return fmt.Errorf("trailing_stop order requires trailing_stop_type and a positive trailing_stop_value")
+		}
+		if r.TrailingStopType == models.TrailingStopTypePercentage && r.TrailingStopValue.GreaterThan(models.NewDecimalFromInt(100)) {
+			return fmt.Errorf("trailing_stop_value %s is over 100 percent", r.TrailingStopValue)
+		}
+	}
+	if r.PriceRange != nil && r.OrderType != models.OrderTypeMarketWithRange {
+		return fmt.Errorf("price_range is only allowed on market_with_range orders")
+	}
+	if (r.TrailingStopType != "" || r.TrailingStopValue != nil) && r.OrderType != models.OrderTypeTrailingStop {
+		return fmt.Errorf("trailing_stop_type and trailing_stop_value are only allowed on trailing_stop orders")
+	}
+	if r.DiscQuantity != nil {
+		if r.OrderType != models.OrderTypeLimit {
+			return fmt.Errorf("disc_quantity is only allowed on limit orders")
+		}
+		if !positive(r.DiscQuantity) || !r.DiscQuantity.LessThan(r.Quantity) {
+			return fmt.Errorf("disc_quantity must be positive and less than quantity")
+		}
+	}
+	return r.validateMargin()
+}
+
+func (r *CreateOrderRequest) validateMargin() error {
+	if r.TradingType == "" || r.TradingType == models.TradingTypeSpot {
+		if r.LeverageLevel > 1 || r.FundingCurrency != "" || r.OrderDirection != "" || r.MarginType != "" || r.TakeProfit != nil || r.StopLoss != nil {
+			return fmt.Errorf("leverage_level, funding_currency, order_direction, margin_type, take_profit and stop_loss require a margin, cfd or perpetual trading_type")
+		}
+		return nil
+	}
+	if r.LeverageLevel < 1 {
+		return fmt.Errorf("%s order requires leverage_level", r.TradingType)
+	}
+	if (r.TakeProfit != nil && r.TakeProfit.Sign() <= 0) || (r.StopLoss != nil && r.StopLoss.Sign() <= 0) {
+		return fmt.Errorf("take_profit and stop_loss must be positive")
+	}
+	// a buy gains when the price rises, so its take profit is above its stop
+	// loss; a sell the other way round
+	if r.TakeProfit != nil && r.StopLoss != nil {
+		if r.Side == models.SideBuy && !r.TakeProfit.GreaterThan(*r.StopLoss) {
+			return fmt.Errorf("take_profit %s of a buy order must be above stop_loss %s", r.TakeProfit, r.StopLoss)
+		}
+		if r.Side == models.SideSell && !r.TakeProfit.LessThan(*r.StopLoss) {
+			return fmt.Errorf("take_profit %s of a sell order must be below stop_loss %s", r.TakeProfit, r.StopLoss)
+		}
+	}
+	return nil
+}
+
+func positive(d *models.Decimal) bool {
+	return d != nil && d.Sign() > 0
+}
+
+// EditOrderRequest is the body of PUT /orders/:id.
+type EditOrderRequest struct {
+	Quantity *models.Decimal `json:"quantity,omitempty"`
+	Price    *models.Decimal `json:"price,omitempty"`
+}
+
+// LoanBidRequest is the body of POST /loan_bids.
+type LoanBidRequest struct {
+	Quantity models.Decimal `json:"quantity"`
+	Currency string         `json:"currency"`
+	Rate     models.Decimal `json:"rate"`
+}
+
+// EditTradeRequest is the body of PUT /trades/:id.
+type EditTradeRequest struct {
+	StopLoss   *models.Decimal `json:"stop_loss,omitempty"`
+	TakeProfit *models.Decimal `json:"take_profit,omitempty"`
+}
+
+// EditTradingAccountRequest is the body of PUT /trading_accounts/:id.
+type EditTradingAccountRequest struct {
+	LeverageLevel int `json:"leverage_level"`
+}
+
+// FiatAccountRequest is the body of POST /fiat_accounts.
+type FiatAccountRequest struct {
+	Currency string `json:"currency"`
+}
+
+// CryptoWithdrawalRequest is the body of POST /crypto_withdrawals.
+type CryptoWithdrawalRequest struct {
+	Currency string         `json:"currency"`
+	Amount   models.Decimal `json:"amount"`
+	Address  string         `json:"address"`
+	// PaymentID is the destination tag or memo some currencies require.
+	PaymentID string `json:"payment_id,omitempty"`
+	Network   string `json:"network,omitempty"`
+	// AuthCode is the two-factor code, sent beside the withdrawal.
+	AuthCode string `json:"-"`
+}
+
+func (r *CryptoWithdrawalRequest) validate() error {
+	switch {
+	case r.Currency == "":
+		return fmt.Errorf("currency is required")
+	case r.Address == "":
+		return fmt.Errorf("address is required")
+	case r.Amount.Sign() <= 0:
+		return fmt.Errorf("amount must be positive")
+	}
+	return nil
+}
+
+// FiatWithdrawalRequest is the body of POST /fiat_withdrawals.
+type FiatWithdrawalRequest struct {
+	Currency string         `json:"currency"`
+	Amount   models.Decimal `json:"amount"`
+	// BankAccountID is the ID of a registered bank account.
+	BankAccountID int `json:"bank_id"`
+	// AuthCode is the two-factor code, sent beside the withdrawal.
+	AuthCode string `json:"-"`
+}
+
+func (r *FiatWithdrawalRequest) validate() error {
+	switch {
+	case r.Currency == "":
+		return fmt.Errorf("currency is required")
+	case r.BankAccountID <= 0:
+		return fmt.Errorf("bank account is required")
+	case r.Amount.Sign() <= 0:
+		return fmt.Errorf("amount must be positive")
+	}
+	return nil
+}
+
+type orderEnvelope struct {
+	Order interface{} `json:"order"`
+}
+
+type loanBidEnvelope struct {
+	LoanBid *LoanBidRequest `json:"loan_bid"`
+}
+
+type tradeEnvelope struct {
+	Trade *EditTradeRequest `json:"trade"`
+}
+
+type tradingAccountEnvelope struct {
+	TradingAccount *EditTradingAccountRequest `json:"trading_account"`
+}
+
+type cryptoWithdrawalEnvelope struct {
+	AuthCode         string                   `json:"auth_code,omitempty"`
+	CryptoWithdrawal *CryptoWithdrawalRequest `json:"crypto_withdrawal"`
+}
+
+type fiatWithdrawalEnvelope struct {
+	AuthCode       string                 `json:"auth_code,omitempty"`
+	FiatWithdrawal *FiatWithdrawalRequest `json:"fiat_withdrawal"`
+}
+
+type loanEnvelope struct {
+	Loan struct {
+		FundReloaned bool `json:"fund_reloaned"`
+	} `json:"loan"`
+}
+
+type closeTradeBody struct {
+	ClosedQuantity models.Decimal `json:"closed_quantity"`
+}
+
+type closeAllTradeBody struct {
+	Side models.TradeSide `json:"side,omitempty"`
+}
+
+// optionalDecimal maps the zero value, which the positional methods use for
+// "not set", to an omitted field.
+func optionalDecimal(d models.Decimal) *models.Decimal {
+	if d.IsZero() {
+		return nil
+	}
+	return &d
+}
+
+func jsonBody(v interface{}) (io.Reader, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(b), nil
+}
@@ -0,0 +1,257 @@
+package quoinex
+
+import (
+	"context"
+	"errors"
+	"github.com/sho3imo/quoinex-go-client/v2/models"
+	"github.com/sho3imo/quoinex-go-client/v2/testutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func decimalPtr(s string) *models.Decimal {
+	d := models.MustDecimal(s)
+	return &d
+}
+
+func TestRequestBodies(t *testing.T) {
+	type Param struct {
+		call         func(c *Client, ctx context.Context) error
+		jsonResponse string
+	}
+	type Expect struct {
+		path   string
+		method string
+		body   string
+	}
+	cases := []struct {
+		param  Param
+		expect Expect
+	}{
+		// test case 1: user input is escaped
+		{
+			param: Param{call: func(c *Client, ctx context.Context) error {
+				_, err := c.CreateOrder(ctx, &CreateOrderRequest{OrderType: "market", ProductID: 5, Side: "buy", Quantity: models.MustDecimal("0.01"), ClientOrderID: `bot "a"\1`})
+				return err
+			}, jsonResponse: testutil.GetCreateAnOrderJsonResponse()},
+			expect: Expect{path: "/orders/", method: "POST", body: `{"order":{"order_type":"market","product_id":5,"side":"buy","quantity":"0.01","client_order_id":"bot \"a\"\\1"}}`},
+		},
+		// test case 2: optional fields are omitted
+		{
+			param: Param{call: func(c *Client, ctx context.Context) error {
+				_, err := c.EditOrder(ctx, 2157474, &EditOrderRequest{Price: decimalPtr("520.0")})
+				return err
+			}, jsonResponse: testutil.GetEditALiveOrderJsonResponse()},
+			expect: Expect{path: "/orders/2157474", method: "PUT", body: `{"order":{"price":"520.0"}}`},
+		},
+		// test case 3
+		{
+			param: Param{call: func(c *Client, ctx context.Context) error {
+				_, err := c.CreateLoanBid(ctx, &LoanBidRequest{Quantity: models.MustDecimal("50"), Currency: "USD", Rate: models.MustDecimal("0.0002")})
+				return err
+			}, jsonResponse: testutil.GetCreateLoanBidJsonResponse()},
+			expect: Expect{path: "/loan_bids", method: "POST", body: testutil.GetExpectedCreateALoanBidRequestBody()},
+		},
+		// test case 4
+		{
+			param: Param{call: func(c *Client, ctx context.Context) error {
+				_, err := c.EditTrade(ctx, 57897, &EditTradeRequest{TakeProfit: decimalPtr("600")})
+				return err
+			}, jsonResponse: testutil.GetUpdateTradeJsonResponse()},
+			expect: Expect{path: "/trades/57897", method: "PUT", body: `{"trade":{"take_profit":"600"}}`},
+		},
+		// test case 5
+		{
+			param: Param{call: func(c *Client, ctx context.Context) error {
+				_, err := c.EditTradingAccount(ctx, 1759, &EditTradingAccountRequest{LeverageLevel: 25})
+				return err
+			}, jsonResponse: testutil.GetUpdateLeverageLevelJsonResponse()},
+			expect: Expect{path: "/trading_accounts/1759", method: "PUT", body: testutil.GetExpectedUpdateLeverageLevelRequestBody()},
+		},
+		// test case 6
+		{
+			param: Param{call: func(c *Client, ctx context.Context) error {
+				_, err := c.CreateFiatAccount(ctx, &FiatAccountRequest{Currency: "USD"})
+				return err
+			}, jsonResponse: testutil.GetCreateFiatAccountJsonResponse()},
+			expect: Expect{path: "/fiat_accounts", method: "POST", body: `{"currency":"USD"}`},
+		},
+		// test case 7
+		{
+			param: Param{call: func(c *Client, ctx context.Context) error {
+				_, err := c.CreateOrder(ctx, &CreateOrderRequest{OrderType: "limit", ProductID: 5, Side: "sell", Quantity: models.MustDecimal("1.0"),
+					Price: decimalPtr("500"), TradingType: "margin", LeverageLevel: 10, FundingCurrency: "USD", OrderDirection: "netout",
+					MarginType: "cross", TakeProfit: decimalPtr("450"), StopLoss: decimalPtr("550")})
+				return err
+			}, jsonResponse: testutil.GetCreateAnOrderJsonResponse()},
+			expect: Expect{path: "/orders/", method: "POST", body: `{"order":{"order_type":"limit","product_id":5,"side":"sell","quantity":"1.0","price":"500",` +
+				`"trading_type":"margin","leverage_level":10,"funding_currency":"USD","order_direction":"netout","margin_type":"cross","take_profit":"450","stop_loss":"550"}}`},
+		},
+		// test case 8
+		{
+			param: Param{call: func(c *Client, ctx context.Context) error {
+				_, err := c.CreateOrder(ctx, &CreateOrderRequest{OrderType: "trailing_stop", ProductID: 5, Side: "sell", Quantity: models.MustDecimal("1.0"),
+					TrailingStopType: "percentage", TrailingStopValue: decimalPtr("2.5")})
+				return err
+			}, jsonResponse: testutil.GetCreateAnOrderJsonResponse()},
+			expect: Expect{path: "/orders/", method: "POST", body: `{"order":{"order_type":"trailing_stop","product_id":5,"side":"sell","quantity":"1.0","trailing_stop_type":"percentage","trailing_stop_value":"2.5"}}`},
+		},
+		// test case 9: iceberg
+		{
+			param: Param{call: func(c *Client, ctx context.Context) error {
+				_, err := c.CreateOrder(ctx, &CreateOrderRequest{OrderType: "limit", ProductID: 5, Side: "buy", Quantity: models.MustDecimal("10"),
+					Price: decimalPtr("500"), DiscQuantity: decimalPtr("1")})
+				return err
+			}, jsonResponse: testutil.GetCreateAnOrderJsonResponse()},
+			expect: Expect{path: "/orders/", method: "POST", body: `{"order":{"order_type":"limit","product_id":5,"side":"buy","quantity":"10","price":"500","disc_quantity":"1"}}`},
+		},
+	}
+	for _, c := range cases {
+		ts := testutil.GenerateTestServer(t, c.expect.path, c.expect.method, c.expect.body, c.param.jsonResponse)
+		defer ts.Close()
+
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := c.param.call(client, ctx); err != nil {
+			t.Errorf("Error. %+v", err)
+		}
+	}
+}
+
+func TestCreateOrderRequestValidate(t *testing.T) {
+	type Param struct {
+		req CreateOrderRequest
+	}
+	type Expect struct {
+		err bool
+	}
+	qty := models.MustDecimal("1")
+	cases := []struct {
+		param  Param
+		expect Expect
+	}{
+		// test case 1
+		{param: Param{req: CreateOrderRequest{OrderType: "market", Side: "buy", Quantity: qty}}, expect: Expect{err: false}},
+		// test case 2
+		{param: Param{req: CreateOrderRequest{OrderType: "market", Side: "buy"}}, expect: Expect{err: true}},
+		// test case 3
+		{param: Param{req: CreateOrderRequest{OrderType: "limit", Side: "buy", Quantity: qty}}, expect: Expect{err: true}},
+		// test case 4
+		{param: Param{req: CreateOrderRequest{OrderType: "stop", Side: "sell", Quantity: qty, Price: decimalPtr("450")}}, expect: Expect{err: false}},
+		// test case 5
+		{param: Param{req: CreateOrderRequest{OrderType: "stop", Side: "sell", Quantity: qty}}, expect: Expect{err: true}},
+		// test case 6
+		{param: Param{req: CreateOrderRequest{OrderType: "market_with_range", Side: "buy", Quantity: qty}}, expect: Expect{err: true}},
+		// test case 7
+		{param: Param{req: CreateOrderRequest{OrderType: "market", Side: "buy", Quantity: qty, PriceRange: decimalPtr("10")}}, expect: Expect{err: true}},
+		// test case 8
+		{param: Param{req: CreateOrderRequest{OrderType: "trailing_stop", Side: "sell", Quantity: qty, TrailingStopType: "fiat"}}, expect: Expect{err: true}},
+		// test case 9
+		{param: Param{req: CreateOrderRequest{OrderType: "trailing_stop", Side: "sell", Quantity: qty, TrailingStopType: "percentage", TrailingStopValue: decimalPtr("101")}}, expect: Expect{err: true}},
+		// test case 10
+		{param: Param{req: CreateOrderRequest{OrderType: "limit", Side: "sell", Quantity: qty, Price: decimalPtr("450"), TrailingStopValue: decimalPtr("1")}}, expect: Expect{err: true}},
+		// test case 11: iceberg slice must be smaller than the order
+		{param: Param{req: CreateOrderRequest{OrderType: "limit", Side: "buy", Quantity: qty, Price: decimalPtr("450"), DiscQuantity: decimalPtr("1")}}, expect: Expect{err: true}},
+		// test case 12
+		{param: Param{req: CreateOrderRequest{OrderType: "market", Side: "buy", Quantity: qty, DiscQuantity: decimalPtr("0.1")}}, expect: Expect{err: true}},
+		// test case 13
+		{param: Param{req: CreateOrderRequest{OrderType: "market", Side: "buy", Quantity: qty, LeverageLevel: 10}}, expect: Expect{err: true}},
+		// test case 14
+		{param: Param{req: CreateOrderRequest{OrderType: "market", Side: "buy", Quantity: qty, TradingType: "cfd"}}, expect: Expect{err: true}},
+		// test case 15
+		{param: Param{req: CreateOrderRequest{OrderType: "market", Side: "buy", Quantity: qty, TradingType: "perpetual", LeverageLevel: 25,
+			TakeProfit: decimalPtr("550"), StopLoss: decimalPtr("450")}}, expect: Expect{err: false}},
+		// test case 16
+		{param: Param{req: CreateOrderRequest{OrderType: "market", Side: "buy", Quantity: qty, TradingType: "perpetual", LeverageLevel: 25,
+			TakeProfit: decimalPtr("450"), StopLoss: decimalPtr("550")}}, expect: Expect{err: true}},
+		// test case 17
+		{param: Param{req: CreateOrderRequest{OrderType: "market", Side: "buy", Quantity: qty, TradingType: "margin", LeverageLevel: 2, OrderDirection: "both"}}, expect: Expect{err: true}},
+	}
+	for i, c := range cases {
+		if err := c.param.req.validate(); (err != nil) != c.expect.err {
+			t.Errorf("Wrong validation in case %d. %+v", i+1, err)
+		}
+	}
+}
+
+func TestNilRequest(t *testing.T) {
+	client, _ := NewClient("apiTokenID", "secret")
+	ctx := context.Background()
+	if _, err := client.CreateOrder(ctx, nil); err == nil {
+		t.Errorf("CreateOrder must reject a nil request")
+	}
+	if _, err := client.EditOrder(ctx, 1, nil); err == nil {
+		t.Errorf("EditOrder must reject a nil request")
+	}
+}
+
+func TestInvalidEnumFailsFast(t *testing.T) {
+	type Param struct {
+		call func(c *Client, ctx context.Context) error
+	}
+	type Expect struct {
+		typ   string
+		value string
+	}
+	cases := []struct {
+		param  Param
+		expect Expect
+	}{
+		// test case 1
+		{
+			param: Param{call: func(c *Client, ctx context.Context) error {
+				_, err := c.CreateOrder(ctx, &CreateOrderRequest{OrderType: "limit", ProductID: 1, Side: "by", Quantity: models.MustDecimal("1")})
+				return err
+			}},
+			expect: Expect{typ: "side", value: "by"},
+		},
+		// test case 2
+		{
+			param: Param{call: func(c *Client, ctx context.Context) error {
+				_, err := c.CreateAnOrder(ctx, "limt", "buy", models.MustDecimal("1"), models.Decimal{}, models.Decimal{}, 1, "")
+				return err
+			}},
+			expect: Expect{typ: "order type", value: "limt"},
+		},
+		// test case 3
+		{
+			param: Param{call: func(c *Client, ctx context.Context) error {
+				_, err := c.GetOrders(ctx, 1, 0, "USD", "open")
+				return err
+			}},
+			expect: Expect{typ: "order status", value: "open"},
+		},
+		// test case 4
+		{
+			param: Param{call: func(c *Client, ctx context.Context) error {
+				_, err := c.Orders(ctx, OrderFilter{Status: "canceled"}).All()
+				return err
+			}},
+			expect: Expect{typ: "order status", value: "canceled"},
+		},
+		// test case 5
+		{
+			param: Param{call: func(c *Client, ctx context.Context) error {
+				_, err := c.CloseAllTrade(ctx, "sell")
+				return err
+			}},
+			expect: Expect{typ: "trade side", value: "sell"},
+		},
+	}
+	for _, c := range cases {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("request must not be sent: %s %s", r.Method, r.URL)
+		}))
+		defer ts.Close()
+
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
+		err := c.param.call(client, context.Background())
+		var enumErr *models.InvalidEnumError
+		if !errors.As(err, &enumErr) || enumErr.Type != c.expect.typ || enumErr.Value != c.expect.value {
+			t.Errorf("Wrong error. %+v", err)
+		}
+	}
+}
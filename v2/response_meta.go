@@ -0,0 +1,76 @@
+package quoinex
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// ResponseMeta describes how a call went on the wire. Pass one to
+// ContextWithResponseMeta to have it filled in.
+type ResponseMeta struct {
+	// StatusCode and Header are from the last response; both are zero when
+	// no response was received.
+	StatusCode int
+	Header     http.Header
+	RequestID  string
+	// Date is the server's Date header, useful to spot clock skew.
+	Date time.Time
+	// Nonce is the nonce signed into the last attempt.
+	Nonce    int64
+	Attempts int
+	// Latency is the round trip of the last attempt; Duration covers the
+	// whole call including rate-limit waits and retry backoff.
+	Latency  time.Duration
+	Duration time.Duration
+	// RetryAfter is the server's Retry-After hint, if any.
+	RetryAfter time.Duration
+	// RateLimit is the client limiter's budget once the call finished.
+	RateLimit RateLimitBudget
+}
+
+type responseMetaKey struct{}
+
+// ContextWithResponseMeta makes calls made with ctx record their response
+// metadata into meta. Concurrent calls must not share one meta. Lookups a
+// method makes on its own, like the minimum check of
+// CreateCryptoWithdrawal, are not recorded.
+func ContextWithResponseMeta(ctx context.Context, meta *ResponseMeta) context.Context {
+	return context.WithValue(ctx, responseMetaKey{}, meta)
+}
+
+func responseMetaFrom(ctx context.Context) *ResponseMeta {
+	meta, _ := ctx.Value(responseMetaKey{}).(*ResponseMeta)
+	return meta
+}
+
+// withoutResponseMeta returns ctx for a request made on the way to the one
+// the caller asked for, so that it leaves the caller's ResponseMeta alone.
+func withoutResponseMeta(ctx context.Context) context.Context {
+	if responseMetaFrom(ctx) == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, responseMetaKey{}, (*ResponseMeta)(nil))
+}
+
+// recordAttempt overwrites the per-attempt fields with the outcome of call.
+func (m *ResponseMeta) recordAttempt(call *Request, res *Response, err error, latency time.Duration, now time.Time) {
+	m.Nonce = call.Nonce
+	m.Attempts = call.Attempt
+	m.Latency = latency
+	m.StatusCode, m.Header = 0, nil
+
+	var apiErr *APIError
+	if res != nil && res.HTTPResponse != nil {
+		m.StatusCode, m.Header = res.HTTPResponse.StatusCode, res.HTTPResponse.Header
+	} else if errors.As(err, &apiErr) {
+		m.StatusCode, m.Header = apiErr.StatusCode, apiErr.Header
+	}
+	m.RequestID, m.Date, m.RetryAfter = "", time.Time{}, 0
+	if m.Header != nil {
+		m.RequestID = m.Header.Get("X-Request-Id")
+		m.Date, _ = http.ParseTime(m.Header.Get("Date"))
+		m.RetryAfter = retryAfter(err, now)
+	}
+}
@@ -0,0 +1,161 @@
+package quoinex
+
+import (
+	"context"
+	"github.com/sho3imo/quoinex-go-client/v2/models"
+	"github.com/sho3imo/quoinex-go-client/v2/testutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestResponseMeta(t *testing.T) {
+	ts := testutil.GenerateTestServer(t, "/products/1", "GET", "", testutil.GetProductJsonResponse())
+	defer ts.Close()
+
+	client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL), WithRateLimiter(NewRateLimiter(10, time.Minute)))
+	var meta ResponseMeta
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := client.GetProduct(ContextWithResponseMeta(ctx, &meta), 1); err != nil {
+		t.Errorf("Error. %+v", err)
+	}
+
+	if meta.StatusCode != 200 || meta.Header.Get("Date") == "" {
+		t.Errorf("Wrong response. %+v", meta)
+	}
+	if meta.Attempts != 1 || meta.Nonce == 0 || meta.Date.IsZero() {
+		t.Errorf("Wrong attempt. %+v", meta)
+	}
+	if meta.Latency <= 0 || meta.Duration < meta.Latency {
+		t.Errorf("Wrong timing. latency: %v, duration: %v", meta.Latency, meta.Duration)
+	}
+	if meta.RateLimit.Capacity != 10 || meta.RateLimit.Remaining != 9 {
+		t.Errorf("Wrong rate limit. %+v", meta.RateLimit)
+	}
+}
+
+func TestResponseMetaOnRetriedError(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("X-Request-Id", "req-"+string(rune('0'+attempts)))
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	var nonces []int64
+	recorder := func(next RoundTripFunc) RoundTripFunc {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			nonces = append(nonces, req.Nonce)
+			return next(ctx, req)
+		}
+	}
+	client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL), WithMiddleware(recorder),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}))
+	var meta ResponseMeta
+	if _, err := client.GetProduct(ContextWithResponseMeta(context.Background(), &meta), 1); !IsRateLimited(err) {
+		t.Errorf("Wrong error. %+v", err)
+	}
+	if meta.StatusCode != 429 || meta.Attempts != 2 || meta.RequestID != "req-2" {
+		t.Errorf("Wrong meta. %+v", meta)
+	}
+	if len(nonces) != 2 || meta.Nonce != nonces[1] || nonces[0] >= nonces[1] {
+		t.Errorf("Wrong nonce. meta: %d, signed: %v", meta.Nonce, nonces)
+	}
+}
+
+// stepClock advances a second every time it is read.
+type stepClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *stepClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(time.Second)
+	return c.now
+}
+
+func TestResponseMetaUsesClock(t *testing.T) {
+	ts := testutil.GenerateTestServer(t, "/products/1", "GET", "", testutil.GetProductJsonResponse())
+	defer ts.Close()
+
+	client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL), WithClock(&stepClock{now: time.Unix(1000, 0)}))
+	var meta ResponseMeta
+	if _, err := client.GetProduct(ContextWithResponseMeta(context.Background(), &meta), 1); err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	if meta.Duration < time.Second || meta.Duration%time.Second != 0 {
+		t.Errorf("Wrong duration. %v", meta.Duration)
+	}
+}
+
+func TestResponseMetaSkipsInternalRequests(t *testing.T) {
+	type Param struct {
+		setup func(s *testutil.Server)
+		call  func(ctx context.Context, client *Client) error
+	}
+	cases := []struct {
+		param  Param
+		expect string
+	}{
+		// test case 1: the minimum check of a withdrawal
+		{param: Param{
+			setup: func(s *testutil.Server) {
+				s.Expect("GET", "/crypto_accounts").Respond(200, `[{"id":2,"currency":"BTC","minimum_withdraw":"0.001"}]`).WithResponseHeader("X-Request-Id", "accounts")
+				s.Expect("POST", "/crypto_withdrawals").Respond(200, `{"id":11}`).WithResponseHeader("X-Request-Id", "withdrawal")
+			},
+			call: func(ctx context.Context, client *Client) error {
+				_, err := client.CreateCryptoWithdrawal(ctx, &CryptoWithdrawalRequest{Currency: "BTC", Amount: models.MustDecimal("0.5"), Address: "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2"})
+				return err
+			},
+		}, expect: "withdrawal"},
+		// test case 2: the scan before cancelling by client order id
+		{param: Param{
+			setup: func(s *testutil.Server) {
+				s.Expect("PUT", "/orders/client:bot-1/cancel").Respond(404, `{"message":"not found"}`).WithResponseHeader("X-Request-Id", "client")
+				s.Expect("GET", "/orders?client_order_id=bot-1&page=1").Respond(200, `{"models":[{"id":7,"client_order_id":"bot-1"}],"current_page":1,"total_pages":1}`).WithResponseHeader("X-Request-Id", "scan")
+				s.Expect("PUT", "/orders/7/cancel").Respond(200, `{"id":7}`).WithResponseHeader("X-Request-Id", "cancel")
+			},
+			call: func(ctx context.Context, client *Client) error {
+				_, err := client.CancelOrderByClientID(ctx, "bot-1")
+				return err
+			},
+		}, expect: "cancel"},
+		// test case 3: the lookup after a duplicate client_order_id on retry
+		{param: Param{
+			setup: func(s *testutil.Server) {
+				s.Expect("POST", "/orders/").Respond(503, `{"message":"unavailable"}`)
+				s.Expect("POST", "/orders/").Respond(422, `{"errors":{"client_order_id":["exists"]}}`).WithResponseHeader("X-Request-Id", "create")
+				s.Expect("GET", "/orders?client_order_id=bot-1&page=1&product_id=1").Respond(200, `{"models":[{"id":7,"client_order_id":"bot-1"}],"current_page":1,"total_pages":1}`).WithResponseHeader("X-Request-Id", "lookup")
+			},
+			call: func(ctx context.Context, client *Client) error {
+				_, err := client.CreateOrder(ctx, &CreateOrderRequest{OrderType: models.OrderTypeLimit, ProductID: 1, Side: models.SideBuy,
+					Quantity: models.MustDecimal("0.01"), Price: decimalPtr("500"), ClientOrderID: "bot-1"})
+				return err
+			},
+		}, expect: "create"},
+	}
+	for i, c := range cases {
+		s := testutil.NewServer(t).InOrder()
+		c.param.setup(s)
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(s.URL),
+			WithRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}))
+		var meta ResponseMeta
+		if err := c.param.call(ContextWithResponseMeta(context.Background(), &meta), client); err != nil {
+			t.Errorf("Error in case %d. %+v", i+1, err)
+		}
+		if meta.RequestID != c.expect {
+			t.Errorf("Wrong request id in case %d. actual: %s, expect: %s", i+1, meta.RequestID, c.expect)
+		}
+		if err := s.Verify(); err != nil {
+			t.Errorf("Error in case %d. %+v", i+1, err)
+		}
+		s.Close()
+	}
+}
@@ -0,0 +1,112 @@
+package quoinex
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how sendRequest retries network errors, 429 and 5xx
+// responses. GET requests are retried; other methods only when the caller
+// marks them idempotent (e.g. CreateAnOrder with a client_order_id).
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 3, BaseDelay: 200 * time.Millisecond, MaxDelay: 5 * time.Second}
+
+var NoRetryPolicy = RetryPolicy{MaxAttempts: 1}
+
+type retryPolicyKey struct{}
+
+type retryStateKey struct{}
+
+// retryState marks a non-GET request as safe to retry and records how many
+// attempts were made.
+type retryState struct {
+	attempts int
+}
+
+// ContextWithRetryPolicy overrides the client's retry policy for calls made with ctx.
+func ContextWithRetryPolicy(ctx context.Context, policy RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyKey{}, policy)
+}
+
+func withRetryState(ctx context.Context, state *retryState) context.Context {
+	return context.WithValue(ctx, retryStateKey{}, state)
+}
+
+func (c *Client) retryPolicy(ctx context.Context) RetryPolicy {
+	if p, ok := ctx.Value(retryPolicyKey{}).(RetryPolicy); ok {
+		return p
+	}
+	if c.RetryPolicy != nil {
+		return *c.RetryPolicy
+	}
+	return NoRetryPolicy
+}
+
+// backoff returns a full-jitter exponential delay for the given attempt (1-based).
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || d < p.MaxDelay); i++ {
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(d)))
+}
+
+func shouldRetry(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	if apiErr, ok := asAPIError(err); ok {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+	}
+	_, ok := err.(*url.Error)
+	return ok
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func retryAfter(err error, now time.Time) time.Duration {
+	apiErr, ok := asAPIError(err)
+	if !ok || apiErr.Header == nil {
+		return 0
+	}
+	v := apiErr.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
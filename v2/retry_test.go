@@ -0,0 +1,212 @@
+package quoinex
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sho3imo/quoinex-go-client/v2/models"
+	"github.com/sho3imo/quoinex-go-client/v2/testutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+type cannedResponse struct {
+	statusCode int
+	body       string
+}
+
+// sequenceServer replies with responses in order and repeats the last one.
+func sequenceServer(t *testing.T, responses ...cannedResponse) (*httptest.Server, func() []string) {
+	var mu sync.Mutex
+	var calls []string
+	ts := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			calls = append(calls, r.Method+" "+r.URL.RequestURI())
+			res := responses[len(responses)-1]
+			if len(calls) <= len(responses) {
+				res = responses[len(calls)-1]
+			}
+			mu.Unlock()
+
+			w.WriteHeader(res.statusCode)
+			fmt.Fprint(w, res.body)
+		},
+	))
+	return ts, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), calls...)
+	}
+}
+
+func TestRetryGet(t *testing.T) {
+	type Param struct {
+		responses []cannedResponse
+		policy    *RetryPolicy
+	}
+	type Expect struct {
+		calls int
+		err   bool
+	}
+	fastPolicy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+	cases := []struct {
+		param  Param
+		expect Expect
+	}{
+		// test case 1: 5xx then success
+		{
+			param:  Param{responses: []cannedResponse{{503, ""}, {502, ""}, {200, testutil.GetProductJsonResponse()}}},
+			expect: Expect{calls: 3, err: false},
+		},
+		// test case 2: 429 is retried until attempts run out
+		{
+			param:  Param{responses: []cannedResponse{{429, ""}}},
+			expect: Expect{calls: 3, err: true},
+		},
+		// test case 3: 4xx is not retried
+		{
+			param:  Param{responses: []cannedResponse{{400, `{"message":"bad"}`}}},
+			expect: Expect{calls: 1, err: true},
+		},
+		// test case 4: per-call override
+		{
+			param:  Param{responses: []cannedResponse{{503, ""}, {200, testutil.GetProductJsonResponse()}}, policy: &NoRetryPolicy},
+			expect: Expect{calls: 1, err: true},
+		},
+	}
+	for _, c := range cases {
+		ts, calls := sequenceServer(t, c.param.responses...)
+		defer ts.Close()
+
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
+		client.RetryPolicy = &fastPolicy
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if c.param.policy != nil {
+			ctx = ContextWithRetryPolicy(ctx, *c.param.policy)
+		}
+		_, err := client.GetProduct(ctx, 5)
+		if (err != nil) != c.expect.err {
+			t.Errorf("Wrong err. %+v", err)
+		}
+		if len(calls()) != c.expect.calls {
+			t.Errorf("Wrong calls. actual: %+v, expect: %d", calls(), c.expect.calls)
+		}
+	}
+}
+
+func TestRetryCreateAnOrder(t *testing.T) {
+	type Param struct {
+		clientOrderID string
+		responses     []cannedResponse
+	}
+	type Expect struct {
+		calls []string
+		order *models.Order
+		err   bool
+	}
+	orders := `{"models":[{"id":2157474,"client_order_id":"other"},{"id":2157475,"client_order_id":"my-order-1"}],"current_page":1,"total_pages":1}`
+	cases := []struct {
+		param  Param
+		expect Expect
+	}{
+		// test case 1: no client_order_id, never retried
+		{
+			param:  Param{clientOrderID: "", responses: []cannedResponse{{503, ""}}},
+			expect: Expect{calls: []string{"POST /orders/"}, err: true},
+		},
+		// test case 2: duplicate after a retry resolves to the existing order
+		{
+			param: Param{clientOrderID: "my-order-1", responses: []cannedResponse{
+				{503, ""},
+				{422, `{"errors":{"client_order_id":["exists"]}}`},
+				{200, orders},
+			}},
+			expect: Expect{
+				calls: []string{"POST /orders/", "POST /orders/", "GET /orders?client_order_id=my-order-1&page=1&product_id=1"},
+				order: &models.Order{ID: 2157475, ClientOrderID: "my-order-1"},
+			},
+		},
+		// test case 3: duplicate on the first attempt is still an error
+		{
+			param:  Param{clientOrderID: "my-order-1", responses: []cannedResponse{{422, `{"errors":{"client_order_id":["exists"]}}`}}},
+			expect: Expect{calls: []string{"POST /orders/"}, err: true},
+		},
+	}
+	for _, c := range cases {
+		ts, calls := sequenceServer(t, c.param.responses...)
+		defer ts.Close()
+
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
+		client.RetryPolicy = &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		order, err := client.CreateAnOrder(ctx, "limit", "sell", models.MustDecimal("0.01"), models.MustDecimal("500.0"), models.Decimal{}, 1, c.param.clientOrderID)
+		if (err != nil) != c.expect.err {
+			t.Errorf("Wrong err. %+v", err)
+		}
+		if !cmp.Equal(calls(), c.expect.calls) {
+			t.Errorf("Wrong calls. %+v", cmp.Diff(calls(), c.expect.calls))
+		}
+		if !cmp.Equal(order, c.expect.order) {
+			t.Errorf("Wrong attribute. %+v", cmp.Diff(order, c.expect.order))
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		header string
+		expect time.Duration
+	}{
+		{header: "", expect: 0},
+		{header: "3", expect: 3 * time.Second},
+		{header: now.Add(10 * time.Second).Format(http.TimeFormat), expect: 10 * time.Second},
+		{header: "soon", expect: 0},
+	}
+	for _, c := range cases {
+		err := &APIError{StatusCode: 429, Header: http.Header{}}
+		if c.header != "" {
+			err.Header.Set("Retry-After", c.header)
+		}
+		if d := retryAfter(err, now); d != c.expect {
+			t.Errorf("Wrong delay. actual: %s, expect: %s", d, c.expect)
+		}
+	}
+}
+
+func TestRetryAfterUsesClock(t *testing.T) {
+	now := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	var mu sync.Mutex
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			calls++
+			mu.Unlock()
+			w.Header().Set("Retry-After", now.Add(time.Hour).Format(http.TimeFormat))
+			w.WriteHeader(503)
+		},
+	))
+	defer ts.Close()
+
+	// an hour after the client's clock is past the deadline, while by the
+	// wall clock the date is long gone and the retry would go out at once
+	client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL), WithClock(&steppingClock{times: []time.Time{now}}))
+	client.RetryPolicy = &RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := client.GetProduct(ctx, 1); err == nil {
+		t.Errorf("Wrong err. %+v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if calls != 1 {
+		t.Errorf("Wrong calls. %d", calls)
+	}
+}
@@ -0,0 +1,224 @@
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// scrubbedHeaders are replaced in recorded cassettes; X-Quoine-Auth carries
+// the token id and the signed nonce.
+var scrubbedHeaders = []string{"X-Quoine-Auth", "Authorization", "Cookie", "Set-Cookie"}
+
+const scrubbed = "[SCRUBBED]"
+
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Query  string      `json:"query,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body"`
+}
+
+// Interaction is one request/response pair of a cassette.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+func scrubHeader(h http.Header) http.Header {
+	out := make(http.Header, len(h))
+	for k, v := range h {
+		out[k] = append([]string(nil), v...)
+	}
+	for _, k := range scrubbedHeaders {
+		if out.Get(k) != "" {
+			out.Set(k, scrubbed)
+		}
+	}
+	return out
+}
+
+// RecordingTransport forwards requests to Transport and appends every
+// request/response pair, with credentials scrubbed, to the JSON cassette
+// at Path. The file is rewritten after each interaction.
+type RecordingTransport struct {
+	Path string
+	// Transport sends the requests; nil means http.DefaultTransport.
+	Transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette cassette
+}
+
+func NewRecordingTransport(path string, transport http.RoundTripper) *RecordingTransport {
+	return &RecordingTransport{Path: path, Transport: transport}
+}
+
+// RoundTrip sends a clone of req, since a RoundTripper must not modify the
+// request it is given.
+func (t *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	var reqBody []byte
+	if req.Body != nil {
+		body := req.Body
+		if req.GetBody != nil {
+			req.Body.Close()
+			var err error
+			if body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+		b, err := ioutil.ReadAll(body)
+		body.Close()
+		if err != nil {
+			return nil, err
+		}
+		reqBody = b
+		out.Body = ioutil.NopCloser(bytes.NewReader(b))
+	}
+
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	res, err := transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	resBody, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(resBody))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cassette.Interactions = append(t.cassette.Interactions, Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			Path:   req.URL.Path,
+			Query:  req.URL.Query().Encode(),
+			Header: scrubHeader(req.Header),
+			Body:   string(reqBody),
+		},
+		Response: RecordedResponse{
+			StatusCode: res.StatusCode,
+			Header:     scrubHeader(res.Header),
+			Body:       string(resBody),
+		},
+	})
+	if err := t.save(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (t *RecordingTransport) save() error {
+	b, err := json.MarshalIndent(&t.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(t.Path, b, 0644)
+}
+
+// ReplayTransport serves the responses of a cassette. A request matches the
+// first unused interaction with the same method, path and query; anything
+// else fails the round trip with an error naming the request.
+type ReplayTransport struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+	unmatched    []string
+}
+
+func NewReplayTransport(path string) (*ReplayTransport, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var c cassette
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("cassette %s: %v", path, err)
+	}
+	return &ReplayTransport{interactions: c.Interactions, used: make([]bool, len(c.Interactions))}, nil
+}
+
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	query := req.URL.Query().Encode()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, in := range t.interactions {
+		if t.used[i] || in.Request.Method != req.Method || in.Request.Path != req.URL.Path || in.Request.Query != query {
+			continue
+		}
+		t.used[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        in.Response.Header,
+			Body:          ioutil.NopCloser(strings.NewReader(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	desc := req.Method + " " + req.URL.Path
+	if query != "" {
+		desc += "?" + query
+	}
+	t.unmatched = append(t.unmatched, desc)
+	return nil, fmt.Errorf("replay: no recorded interaction for %s", desc)
+}
+
+// Verify reports requests that matched nothing and recorded interactions
+// that were never replayed.
+func (t *ReplayTransport) Verify() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var problems []string
+	for _, u := range t.unmatched {
+		problems = append(problems, "unmatched request "+u)
+	}
+	for i, in := range t.interactions {
+		if !t.used[i] {
+			problems = append(problems, fmt.Sprintf("unused interaction %s %s", in.Request.Method, in.Request.Path))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("replay: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// LoadReplayTransport is NewReplayTransport for tests: it fails t when the
+// cassette cannot be read.
+func LoadReplayTransport(t *testing.T, path string) *ReplayTransport {
+	rt, err := NewReplayTransport(path)
+	if err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	return rt
+}
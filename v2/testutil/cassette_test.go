@@ -0,0 +1,28 @@
+package testutil
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRecordingTransportLeavesRequestAlone(t *testing.T) {
+	s := NewServer(t)
+	defer s.Close()
+	s.Expect("POST", "/orders").WithJSONBody(`{"order":{"quantity":"1"}}`)
+
+	transport := NewRecordingTransport(t.TempDir()+"/cassette.json", nil)
+	req, _ := http.NewRequest("POST", s.URL+"/orders", strings.NewReader(`{"order":{"quantity":"1"}}`))
+	body := req.Body
+	res, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	res.Body.Close()
+	if req.Body != body {
+		t.Errorf("Wrong request. RoundTrip replaced its body")
+	}
+	if err := s.Verify(); err != nil {
+		t.Errorf("Error. %+v", err)
+	}
+}
@@ -0,0 +1,1459 @@
+package testutil
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/sho3imo/quoinex-go-client/v2/models"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Credentials accepted by a FakeExchange unless TokenID or Secret is changed.
+const (
+	FakeTokenID = "apiTokenID"
+	FakeSecret  = "secret"
+)
+
+// FakeExchange is a stateful, in-memory Liquid API for offline integration
+// tests. Every request must carry a valid X-Quoine-Auth JWT: signed with
+// Secret, issued for TokenID and the request path, and with a nonce greater
+// than any seen before (see NonceWindow).
+//
+// Limit orders rest on the book and are matched by price-time priority at the
+// maker's price; market orders take liquidity and the unfilled rest is
+// cancelled. Stop and trailing_stop orders are stored live but never
+// triggered. All orders belong to the one fake user, and fills do not settle
+// account balances.
+type FakeExchange struct {
+	*httptest.Server
+	TokenID string
+	Secret  string
+	// Now stamps created_at and updated_at; it defaults to time.Now.
+	Now func() time.Time
+	// PageSize is the limit of paginated endpoints when the request sets none.
+	PageSize int
+	// NonceWindow lets an unused nonce arrive after up to NonceWindow larger
+	// ones, as requests sent concurrently can. The default 0 is strict.
+	NonceWindow int
+
+	t               *testing.T
+	mu              sync.Mutex
+	nextID          int
+	seq             int
+	nonces          []int64
+	products        []*models.Product
+	orders          []*models.Order
+	priority        map[int]int
+	executions      []*fakeExecution
+	fiatAccounts    []*models.Account
+	cryptoAccounts  []*models.CryptoAccount
+	trades          []*models.Trade
+	loans           []*models.Loan
+	loanBids        []*models.LoanBid
+	tradingAccounts []*models.TradingAccount
+	withdrawals     []*models.CryptoWithdrawal
+	addresses       []*models.WithdrawalAddress
+	fiatDeposits    []*models.FiatDeposit
+	fiatWithdrawals []*models.FiatWithdrawal
+	bankAccounts    []*models.BankAccount
+	failures        []*Failure
+	requests        []string
+}
+
+type fakeExecution struct {
+	productID int
+	models.ExecutionsModels
+}
+
+// Failure makes matching requests fail before authentication and routing,
+// like an overloaded gateway in front of the exchange.
+type Failure struct {
+	// Method and Path select requests; empty matches any.
+	Method     string
+	Path       string
+	StatusCode int
+	// Body defaults to {"message":"<status text>"}.
+	Body   string
+	Header http.Header
+	// Times is the number of requests to fail; 0 means one.
+	Times int
+}
+
+// NewFakeExchange starts a FakeExchange seeded with the BTCUSD (1) and
+// BTCJPY (5) products, a USD fiat account and a BTC crypto account. Close it
+// when the test is done.
+func NewFakeExchange(t *testing.T) *FakeExchange {
+	f := &FakeExchange{
+		TokenID:  FakeTokenID,
+		Secret:   FakeSecret,
+		Now:      time.Now,
+		PageSize: 20,
+		t:        t,
+		nextID:   1000,
+		priority: map[int]int{},
+	}
+	f.AddProduct(&models.Product{ID: "1", ProductType: "CurrencyPair", Code: "CASH", Name: "CASH Trading", Currency: "USD",
+		CurrencyPairCode: "BTCUSD", Symbol: "$", QuotedCurrency: "USD", BaseCurrency: "BTC"})
+	f.AddProduct(&models.Product{ID: "5", ProductType: "CurrencyPair", Code: "CASH", Name: "CASH Trading", Currency: "JPY",
+		CurrencyPairCode: "BTCJPY", Symbol: "¥", QuotedCurrency: "JPY", BaseCurrency: "BTC"})
+	f.AddFiatAccount(&models.Account{ID: 1, Currency: "USD", CurrencySymbol: "$", Balance: models.MustDecimal("10000.0"), CurrencyType: "fiat"})
+	f.AddCryptoAccount(&models.CryptoAccount{ID: 2, Currency: "BTC", CurrencySymbol: "₿", Balance: models.MustDecimal("1.0"),
+		MinimumWithdraw: models.MustDecimal("0.001"), CurrencyType: "crypto"})
+	f.Server = httptest.NewServer(f)
+	return f
+}
+
+func (f *FakeExchange) id() int {
+	f.nextID++
+	return f.nextID
+}
+
+func (f *FakeExchange) now() models.Timestamp {
+	return models.NewTimestamp(f.Now())
+}
+
+func (f *FakeExchange) AddProduct(p *models.Product) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	cp := *p
+	f.products = append(f.products, &cp)
+}
+
+func (f *FakeExchange) AddFiatAccount(a *models.Account) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	cp := *a
+	if cp.ID == 0 {
+		cp.ID = f.id()
+	}
+	f.fiatAccounts = append(f.fiatAccounts, &cp)
+}
+
+func (f *FakeExchange) AddCryptoAccount(a *models.CryptoAccount) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	cp := *a
+	if cp.ID == 0 {
+		cp.ID = f.id()
+	}
+	f.cryptoAccounts = append(f.cryptoAccounts, &cp)
+}
+
+func (f *FakeExchange) AddTrade(tr *models.Trade) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	cp := *tr
+	if cp.ID == 0 {
+		cp.ID = f.id()
+	}
+	f.trades = append(f.trades, &cp)
+}
+
+func (f *FakeExchange) AddLoan(l *models.Loan) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	cp := *l
+	if cp.ID == 0 {
+		cp.ID = f.id()
+	}
+	f.loans = append(f.loans, &cp)
+}
+
+func (f *FakeExchange) AddLoanBid(b *models.LoanBid) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	cp := *b
+	if cp.ID == 0 {
+		cp.ID = f.id()
+	}
+	f.loanBids = append(f.loanBids, &cp)
+}
+
+func (f *FakeExchange) AddTradingAccount(a *models.TradingAccount) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	cp := *a
+	if cp.ID == 0 {
+		cp.ID = f.id()
+	}
+	f.tradingAccounts = append(f.tradingAccounts, &cp)
+}
+
+func (f *FakeExchange) AddWithdrawalAddress(a *models.WithdrawalAddress) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	cp := *a
+	if cp.ID == 0 {
+		cp.ID = f.id()
+	}
+	f.addresses = append(f.addresses, &cp)
+}
+
+func (f *FakeExchange) AddFiatDeposit(d *models.FiatDeposit) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	cp := *d
+	if cp.ID == 0 {
+		cp.ID = f.id()
+	}
+	f.fiatDeposits = append(f.fiatDeposits, &cp)
+}
+
+func (f *FakeExchange) AddBankAccount(a *models.BankAccount) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	cp := *a
+	if cp.ID == 0 {
+		cp.ID = f.id()
+	}
+	f.bankAccounts = append(f.bankAccounts, &cp)
+}
+
+// InjectFailure queues a failure; queued failures are consumed in order.
+func (f *FakeExchange) InjectFailure(fl Failure) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if fl.Times <= 0 {
+		fl.Times = 1
+	}
+	f.failures = append(f.failures, &fl)
+}
+
+// Requests returns every request received so far as "METHOD /path?query".
+func (f *FakeExchange) Requests() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.requests...)
+}
+
+// Order returns a copy of the order, or nil if there is none with that id.
+func (f *FakeExchange) Order(id int) *models.Order {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if o := f.order(id); o != nil {
+		cp := *o
+		cp.Executions = append(models.OrderExecutions{}, o.Executions...)
+		return &cp
+	}
+	return nil
+}
+
+func (f *FakeExchange) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.Method+" "+r.URL.RequestURI())
+
+	if fl := f.takeFailure(r); fl != nil {
+		for k, v := range fl.Header {
+			w.Header()[k] = v
+		}
+		body := fl.Body
+		if body == "" {
+			b, _ := json.Marshal(map[string]string{"message": http.StatusText(fl.StatusCode)})
+			body = string(b)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(fl.StatusCode)
+		fmt.Fprint(w, body)
+		return
+	}
+	if err := f.authenticate(r); err != nil {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"message": err.Error()})
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+		return
+	}
+	// split the escaped path so an escaped "/" stays inside its segment
+	segments := strings.Split(strings.Trim(r.URL.EscapedPath(), "/"), "/")
+	for i, seg := range segments {
+		if s, err := url.PathUnescape(seg); err == nil {
+			segments[i] = s
+		}
+	}
+	for _, route := range fakeRoutes {
+		params, ok := route.match(r.Method, segments)
+		if !ok {
+			continue
+		}
+		status, v := route.handle(f, &fakeRequest{Request: r, params: params, body: body})
+		writeJSON(w, status, v)
+		return
+	}
+	f.t.Errorf("FakeExchange: unexpected request %s %s", r.Method, r.URL.RequestURI())
+	writeJSON(w, http.StatusNotFound, map[string]string{"message": "not found"})
+}
+
+func (f *FakeExchange) takeFailure(r *http.Request) *Failure {
+	for i, fl := range f.failures {
+		if (fl.Method != "" && fl.Method != r.Method) || (fl.Path != "" && fl.Path != r.URL.Path) {
+			continue
+		}
+		fl.Times--
+		if fl.Times == 0 {
+			f.failures = append(f.failures[:i], f.failures[i+1:]...)
+		}
+		return fl
+	}
+	return nil
+}
+
+func (f *FakeExchange) authenticate(r *http.Request) error {
+	raw := r.Header.Get("X-Quoine-Auth")
+	if raw == "" {
+		return errors.New("missing X-Quoine-Auth header")
+	}
+	claims := jwt.MapClaims{}
+	parser := &jwt.Parser{UseJSONNumber: true}
+	if _, err := parser.ParseWithClaims(raw, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+		}
+		return []byte(f.Secret), nil
+	}); err != nil {
+		return fmt.Errorf("invalid signature: %v", err)
+	}
+	if claims["token_id"] != f.TokenID {
+		return fmt.Errorf("unknown token_id %v", claims["token_id"])
+	}
+	if claims["path"] != r.URL.RequestURI() {
+		return fmt.Errorf("path claim %v does not match %s", claims["path"], r.URL.RequestURI())
+	}
+	n, ok := claims["nonce"].(json.Number)
+	if !ok {
+		return errors.New("missing nonce")
+	}
+	nonce, err := n.Int64()
+	if err != nil {
+		return fmt.Errorf("invalid nonce %s", n)
+	}
+	return f.useNonce(nonce)
+}
+
+// useNonce keeps the NonceWindow+1 largest accepted nonces, ascending.
+func (f *FakeExchange) useNonce(nonce int64) error {
+	if len(f.nonces) > 0 && nonce <= f.nonces[0] {
+		return fmt.Errorf("nonce %d is not greater than %d", nonce, f.nonces[0])
+	}
+	i := sort.Search(len(f.nonces), func(i int) bool { return f.nonces[i] >= nonce })
+	if i < len(f.nonces) && f.nonces[i] == nonce {
+		return fmt.Errorf("nonce %d was already used", nonce)
+	}
+	f.nonces = append(f.nonces, 0)
+	copy(f.nonces[i+1:], f.nonces[i:])
+	f.nonces[i] = nonce
+	if n := len(f.nonces) - (f.NonceWindow + 1); n > 0 {
+		f.nonces = f.nonces[n:]
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+type fakeRequest struct {
+	*http.Request
+	params []string
+	body   []byte
+}
+
+// param returns the i-th ":" segment of the route.
+func (r *fakeRequest) param(i int) string {
+	return r.params[i]
+}
+
+func (r *fakeRequest) id(i int) int {
+	n, _ := strconv.Atoi(r.params[i])
+	return n
+}
+
+func (r *fakeRequest) query(key string) string {
+	return r.URL.Query().Get(key)
+}
+
+func (r *fakeRequest) decode(v interface{}) error {
+	if len(r.body) == 0 {
+		return nil
+	}
+	return json.Unmarshal(r.body, v)
+}
+
+type fakeRoute struct {
+	method  string
+	pattern string
+	handle  func(f *FakeExchange, r *fakeRequest) (int, interface{})
+}
+
+func (rt fakeRoute) match(method string, segments []string) ([]string, bool) {
+	parts := strings.Split(rt.pattern, "/")
+	if method != rt.method || len(parts) != len(segments) {
+		return nil, false
+	}
+	var params []string
+	for i, p := range parts {
+		switch {
+		case p == ":id":
+			if _, err := strconv.Atoi(segments[i]); err != nil {
+				return nil, false
+			}
+			params = append(params, segments[i])
+		case strings.Contains(p, "::"):
+			// a "prefix::name" segment captures what follows "prefix:"
+			prefix := p[:strings.Index(p, "::")+1]
+			if !strings.HasPrefix(segments[i], prefix) || len(segments[i]) == len(prefix) {
+				return nil, false
+			}
+			params = append(params, segments[i][len(prefix):])
+		case strings.HasPrefix(p, ":"):
+			params = append(params, segments[i])
+		case p != segments[i]:
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+var fakeRoutes = []fakeRoute{
+	{"GET", "ir_ladders/:currency", (*FakeExchange).getInterestRates},
+	{"GET", "products", (*FakeExchange).getProducts},
+	{"GET", "products/:id", (*FakeExchange).getProduct},
+	{"GET", "products/:id/price_levels", (*FakeExchange).getPriceLevels},
+	{"POST", "orders", (*FakeExchange).createOrder},
+	{"GET", "orders", (*FakeExchange).getOrders},
+	{"GET", "orders/client::client_order_id", viaClientOrderID((*FakeExchange).getOrder)},
+	{"PUT", "orders/client::client_order_id", viaClientOrderID((*FakeExchange).editOrder)},
+	{"PUT", "orders/client::client_order_id/cancel", viaClientOrderID((*FakeExchange).cancelOrder)},
+	{"GET", "orders/:id", (*FakeExchange).getOrder},
+	{"PUT", "orders/:id", (*FakeExchange).editOrder},
+	{"PUT", "orders/:id/cancel", (*FakeExchange).cancelOrder},
+	{"GET", "orders/:id/trades", (*FakeExchange).getOrderTrades},
+	{"GET", "executions", (*FakeExchange).getExecutions},
+	{"GET", "executions/me", (*FakeExchange).getOwnExecutions},
+	{"GET", "fiat_accounts", (*FakeExchange).getFiatAccounts},
+	{"POST", "fiat_accounts", (*FakeExchange).createFiatAccount},
+	{"GET", "crypto_accounts", (*FakeExchange).getCryptoAccounts},
+	{"GET", "accounts/balance", (*FakeExchange).getBalances},
+	{"GET", "trading_accounts", (*FakeExchange).getTradingAccounts},
+	{"GET", "trading_accounts/:id", (*FakeExchange).getTradingAccount},
+	{"PUT", "trading_accounts/:id", (*FakeExchange).editTradingAccount},
+	{"GET", "trades", (*FakeExchange).getTrades},
+	{"PUT", "trades/close_all", (*FakeExchange).closeAllTrades},
+	{"PUT", "trades/:id", (*FakeExchange).editTrade},
+	{"PUT", "trades/:id/close", (*FakeExchange).closeTrade},
+	{"GET", "trades/:id/loans", (*FakeExchange).getTradeLoans},
+	{"GET", "loans", (*FakeExchange).getLoans},
+	{"PUT", "loans/:id", (*FakeExchange).editLoan},
+	{"GET", "loan_bids", (*FakeExchange).getLoanBids},
+	{"POST", "loan_bids", (*FakeExchange).createLoanBid},
+	{"PUT", "loan_bids/:id/close", (*FakeExchange).closeLoanBid},
+	{"GET", "crypto_withdrawals", (*FakeExchange).getCryptoWithdrawals},
+	{"POST", "crypto_withdrawals", (*FakeExchange).createCryptoWithdrawal},
+	{"PUT", "crypto_withdrawals/:id/cancel", (*FakeExchange).cancelCryptoWithdrawal},
+	{"GET", "withdrawal_addresses", (*FakeExchange).getWithdrawalAddresses},
+	{"GET", "fiat_deposits", (*FakeExchange).getFiatDeposits},
+	{"GET", "fiat_withdrawals", (*FakeExchange).getFiatWithdrawals},
+	{"POST", "fiat_withdrawals", (*FakeExchange).createFiatWithdrawal},
+	{"PUT", "fiat_withdrawals/:id/cancel", (*FakeExchange).cancelFiatWithdrawal},
+	{"GET", "bank_accounts", (*FakeExchange).getBankAccounts},
+}
+
+func notFound() (int, interface{}) {
+	return http.StatusNotFound, map[string]string{"message": "not found"}
+}
+
+// unprocessable is a 422 in the shape APIError parses into field codes.
+func unprocessable(field, code string) (int, interface{}) {
+	return http.StatusUnprocessableEntity, map[string]map[string][]string{"errors": {field: {code}}}
+}
+
+func badRequest(err error) (int, interface{}) {
+	return http.StatusBadRequest, map[string]string{"message": err.Error()}
+}
+
+// paginate returns the bounds of the requested page over n items and the
+// page numbers to report.
+func (f *FakeExchange) paginate(r *fakeRequest, n int) (lo, hi, page, totalPages int) {
+	page, _ = strconv.Atoi(r.query("page"))
+	if page < 1 {
+		page = 1
+	}
+	limit, _ := strconv.Atoi(r.query("limit"))
+	if limit < 1 {
+		limit = f.PageSize
+	}
+	totalPages = (n + limit - 1) / limit
+	if totalPages < 1 {
+		totalPages = 1
+	}
+	lo = (page - 1) * limit
+	if lo > n {
+		lo = n
+	}
+	hi = lo + limit
+	if hi > n {
+		hi = n
+	}
+	return lo, hi, page, totalPages
+}
+
+func (f *FakeExchange) product(id int) *models.Product {
+	for _, p := range f.products {
+		if p.ID == strconv.Itoa(id) {
+			return p
+		}
+	}
+	return nil
+}
+
+// productView fills in the market bid and ask from the book.
+func (f *FakeExchange) productView(p *models.Product) *models.Product {
+	cp := *p
+	id, _ := strconv.Atoi(p.ID)
+	buys, sells := f.book(id)
+	cp.MarketBid, cp.MarketAsk = models.Decimal{}, models.Decimal{}
+	if len(buys) > 0 {
+		cp.MarketBid = buys[0][0]
+	}
+	if len(sells) > 0 {
+		cp.MarketAsk = sells[0][0]
+	}
+	return &cp
+}
+
+func (f *FakeExchange) getProducts(r *fakeRequest) (int, interface{}) {
+	products := []*models.Product{}
+	for _, p := range f.products {
+		products = append(products, f.productView(p))
+	}
+	return http.StatusOK, products
+}
+
+func (f *FakeExchange) getProduct(r *fakeRequest) (int, interface{}) {
+	p := f.product(r.id(0))
+	if p == nil {
+		return notFound()
+	}
+	return http.StatusOK, f.productView(p)
+}
+
+func (f *FakeExchange) getPriceLevels(r *fakeRequest) (int, interface{}) {
+	if f.product(r.id(0)) == nil {
+		return notFound()
+	}
+	buys, sells := f.book(r.id(0))
+	if r.query("full") != "1" {
+		if len(buys) > 20 {
+			buys = buys[:20]
+		}
+		if len(sells) > 20 {
+			sells = sells[:20]
+		}
+	}
+	return http.StatusOK, &models.PriceLevels{BuyPriceLevels: buys, SellPriceLevels: sells}
+}
+
+// book aggregates the open quantity of resting orders by price, best first.
+func (f *FakeExchange) book(productID int) (buys, sells [][]models.Decimal) {
+	buys, sells = [][]models.Decimal{}, [][]models.Decimal{}
+	add := func(levels [][]models.Decimal, price, qty models.Decimal) [][]models.Decimal {
+		for _, l := range levels {
+			if l[0].Equal(price) {
+				l[1] = l[1].Add(qty)
+				return levels
+			}
+		}
+		return append(levels, []models.Decimal{price, qty})
+	}
+	for _, o := range f.orders {
+		if o.ProductID != productID || !resting(o) {
+			continue
+		}
+		// an iceberg order shows at most its disc_quantity
+		visible := openQuantity(o)
+		if o.DiscQuantity.Sign() > 0 && o.DiscQuantity.LessThan(visible) {
+			visible = o.DiscQuantity
+		}
+		if o.Side == models.SideBuy {
+			buys = add(buys, o.Price, visible)
+		} else {
+			sells = add(sells, o.Price, visible)
+		}
+	}
+	sort.Slice(buys, func(i, j int) bool { return buys[i][0].GreaterThan(buys[j][0]) })
+	sort.Slice(sells, func(i, j int) bool { return sells[i][0].LessThan(sells[j][0]) })
+	return buys, sells
+}
+
+func openQuantity(o *models.Order) models.Decimal {
+	return o.Quantity.Sub(o.FilledQuantity)
+}
+
+func resting(o *models.Order) bool {
+	return o.Status == models.OrderStatusLive && (o.OrderType == models.OrderTypeLimit || o.OrderType == models.OrderTypeLimitPostOnly)
+}
+
+func (f *FakeExchange) order(id int) *models.Order {
+	for _, o := range f.orders {
+		if o.ID == id {
+			return o
+		}
+	}
+	return nil
+}
+
+type fakeOrderBody struct {
+	Order struct {
+		OrderType         models.OrderType        `json:"order_type"`
+		ProductID         int                     `json:"product_id"`
+		Side              models.Side             `json:"side"`
+		Quantity          models.Decimal          `json:"quantity"`
+		Price             models.Decimal          `json:"price"`
+		PriceRange        models.Decimal          `json:"price_range"`
+		ClientOrderID     string                  `json:"client_order_id"`
+		DiscQuantity      models.Decimal          `json:"disc_quantity"`
+		TradingType       models.TradingType      `json:"trading_type"`
+		LeverageLevel     int                     `json:"leverage_level"`
+		FundingCurrency   string                  `json:"funding_currency"`
+		OrderDirection    models.OrderDirection   `json:"order_direction"`
+		MarginType        models.MarginType       `json:"margin_type"`
+		TakeProfit        models.Decimal          `json:"take_profit"`
+		StopLoss          models.Decimal          `json:"stop_loss"`
+		TrailingStopType  models.TrailingStopType `json:"trailing_stop_type"`
+		TrailingStopValue models.Decimal          `json:"trailing_stop_value"`
+	} `json:"order"`
+}
+
+func (f *FakeExchange) createOrder(r *fakeRequest) (int, interface{}) {
+	var body fakeOrderBody
+	if err := r.decode(&body); err != nil {
+		return badRequest(err)
+	}
+	req := body.Order
+	p := f.product(req.ProductID)
+	switch {
+	case p == nil:
+		return unprocessable("product_id", "invalid")
+	case req.Side == "" || req.Side.Validate() != nil:
+		return unprocessable("side", "invalid")
+	case req.Quantity.Sign() <= 0:
+		return unprocessable("quantity", "must_be_positive")
+	}
+	switch req.OrderType {
+	case models.OrderTypeLimit, models.OrderTypeLimitPostOnly, models.OrderTypeStop:
+		if req.Price.Sign() <= 0 {
+			return unprocessable("price", "must_be_positive")
+		}
+	case models.OrderTypeTrailingStop:
+		if req.TrailingStopType == "" || req.TrailingStopType.Validate() != nil {
+			return unprocessable("trailing_stop_type", "invalid")
+		}
+		if req.TrailingStopValue.Sign() <= 0 {
+			return unprocessable("trailing_stop_value", "must_be_positive")
+		}
+	case models.OrderTypeMarketWithRange:
+		if req.PriceRange.Sign() <= 0 {
+			return unprocessable("price_range", "must_be_positive")
+		}
+	case models.OrderTypeMarket:
+	default:
+		return unprocessable("order_type", "not_supported")
+	}
+	if req.ClientOrderID != "" {
+		for _, o := range f.orders {
+			if o.ClientOrderID == req.ClientOrderID {
+				return unprocessable("client_order_id", "exists")
+			}
+		}
+	}
+
+	now := f.now()
+	o := &models.Order{
+		ID:                f.id(),
+		OrderType:         req.OrderType,
+		Quantity:          req.Quantity,
+		DiscQuantity:      req.DiscQuantity,
+		Side:              req.Side,
+		Price:             req.Price,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+		Status:            models.OrderStatusLive,
+		LeverageLevel:     1,
+		SourceExchange:    "QUOINE",
+		ProductID:         req.ProductID,
+		ProductCode:       p.Code,
+		FundingCurrency:   p.QuotedCurrency,
+		CurrencyPairCode:  p.CurrencyPairCode,
+		Executions:        models.OrderExecutions{},
+		ClientOrderID:     req.ClientOrderID,
+		TradingType:       models.TradingTypeSpot,
+		OrderDirection:    req.OrderDirection,
+		MarginType:        req.MarginType,
+		TakeProfit:        req.TakeProfit,
+		StopLoss:          req.StopLoss,
+		TrailingStopType:  req.TrailingStopType,
+		TrailingStopValue: req.TrailingStopValue,
+	}
+	if req.TradingType != "" {
+		o.TradingType = req.TradingType
+	}
+	if req.LeverageLevel > 0 {
+		o.LeverageLevel = req.LeverageLevel
+	}
+	if req.FundingCurrency != "" {
+		o.FundingCurrency = req.FundingCurrency
+	}
+	f.orders = append(f.orders, o)
+	f.seq++
+	f.priority[o.ID] = f.seq
+	switch o.OrderType {
+	case models.OrderTypeStop, models.OrderTypeTrailingStop:
+		// waits for a trigger the fake never fires
+	case models.OrderTypeMarketWithRange:
+		f.match(o, f.rangeLimit(o, req.PriceRange))
+	default:
+		f.match(o, nil)
+	}
+	return http.StatusOK, o
+}
+
+// rangeLimit is the worst price a market_with_range order may fill at:
+// price_range away from its price, or from the best price on the book when
+// it has none.
+func (f *FakeExchange) rangeLimit(o *models.Order, priceRange models.Decimal) *models.Decimal {
+	ref := o.Price
+	if ref.Sign() <= 0 {
+		best := f.bestMaker(o)
+		if best == nil {
+			return nil
+		}
+		ref = best.Price
+	}
+	limit := ref.Add(priceRange)
+	if o.Side == models.SideSell {
+		limit = ref.Sub(priceRange)
+	}
+	return &limit
+}
+
+// match fills the taker against the best resting orders on the other side
+// until it is filled, nothing crosses or the next price is beyond limit.
+func (f *FakeExchange) match(taker *models.Order, limit *models.Decimal) {
+	for openQuantity(taker).Sign() > 0 {
+		maker := f.bestMaker(taker)
+		if maker == nil {
+			break
+		}
+		if limit != nil && (taker.Side == models.SideBuy && maker.Price.GreaterThan(*limit) ||
+			taker.Side == models.SideSell && maker.Price.LessThan(*limit)) {
+			break
+		}
+		if taker.OrderType == models.OrderTypeLimitPostOnly {
+			taker.Status = models.OrderStatusCancelled
+			return
+		}
+		qty := openQuantity(taker)
+		if m := openQuantity(maker); m.LessThan(qty) {
+			qty = m
+		}
+		f.fill(taker, maker, qty, maker.Price)
+	}
+	if !resting(taker) && taker.Status == models.OrderStatusLive {
+		taker.Status = models.OrderStatusCancelled
+	}
+}
+
+func (f *FakeExchange) bestMaker(taker *models.Order) *models.Order {
+	var best *models.Order
+	for _, o := range f.orders {
+		if o == taker || o.ProductID != taker.ProductID || o.Side == taker.Side || !resting(o) {
+			continue
+		}
+		if resting(taker) {
+			if taker.Side == models.SideBuy && o.Price.GreaterThan(taker.Price) {
+				continue
+			}
+			if taker.Side == models.SideSell && o.Price.LessThan(taker.Price) {
+				continue
+			}
+		}
+		if best == nil {
+			best = o
+			continue
+		}
+		switch c := o.Price.Cmp(best.Price); {
+		case c == 0 && f.priority[o.ID] < f.priority[best.ID],
+			c < 0 && taker.Side == models.SideBuy,
+			c > 0 && taker.Side == models.SideSell:
+			best = o
+		}
+	}
+	return best
+}
+
+func (f *FakeExchange) fill(taker, maker *models.Order, qty, price models.Decimal) {
+	now := f.now()
+	id := f.id()
+	for _, o := range []*models.Order{taker, maker} {
+		o.FilledQuantity = o.FilledQuantity.Add(qty)
+		if openQuantity(o).Sign() <= 0 {
+			o.Status = models.OrderStatusFilled
+		}
+		o.UpdatedAt = now
+		o.Executions = append(o.Executions, models.OrderExecution{
+			ID: id, Quantity: qty, Price: price, TakerSide: taker.Side, MySide: o.Side, CreatedAt: now})
+	}
+	f.executions = append(f.executions, &fakeExecution{productID: taker.ProductID, ExecutionsModels: models.ExecutionsModels{
+		ID: id, Quantity: qty, Price: price, TakerSide: taker.Side, MySide: taker.Side, CreatedAt: now}})
+	if p := f.product(taker.ProductID); p != nil {
+		p.LastTradedPrice = price
+		p.LastTradedQuantity = qty
+	}
+}
+
+// viaClientOrderID serves an /orders/client:{client_order_id} route with
+// the handler of the matching /orders/{id} route.
+func viaClientOrderID(handle func(f *FakeExchange, r *fakeRequest) (int, interface{})) func(f *FakeExchange, r *fakeRequest) (int, interface{}) {
+	return func(f *FakeExchange, r *fakeRequest) (int, interface{}) {
+		clientOrderID := r.param(0)
+		for i := len(f.orders) - 1; i >= 0; i-- {
+			if f.orders[i].ClientOrderID == clientOrderID {
+				r.params[0] = strconv.Itoa(f.orders[i].ID)
+				return handle(f, r)
+			}
+		}
+		return notFound()
+	}
+}
+
+func (f *FakeExchange) getOrder(r *fakeRequest) (int, interface{}) {
+	o := f.order(r.id(0))
+	if o == nil {
+		return notFound()
+	}
+	return http.StatusOK, o
+}
+
+// getOrders lists orders newest first.
+func (f *FakeExchange) getOrders(r *fakeRequest) (int, interface{}) {
+	productID, _ := strconv.Atoi(r.query("product_id"))
+	status := models.OrderStatus(r.query("status"))
+	fundingCurrency := r.query("funding_currency")
+	clientOrderID := r.query("client_order_id")
+	side := models.Side(r.query("side"))
+	tradingType := models.TradingType(r.query("trading_type"))
+	from, _ := strconv.ParseInt(r.query("created_at_gte"), 10, 64)
+	to, _ := strconv.ParseInt(r.query("created_at_lt"), 10, 64)
+	var matched []*models.Order
+	for i := len(f.orders) - 1; i >= 0; i-- {
+		o := f.orders[i]
+		if (productID != 0 && o.ProductID != productID) || (status != "" && o.Status != status) ||
+			(fundingCurrency != "" && o.FundingCurrency != fundingCurrency) || (clientOrderID != "" && o.ClientOrderID != clientOrderID) ||
+			(side != "" && o.Side != side) || (tradingType != "" && o.TradingType != tradingType) || (from != 0 && o.CreatedAt.Unix() < from) || (to != 0 && o.CreatedAt.Unix() >= to) {
+			continue
+		}
+		matched = append(matched, o)
+	}
+	lo, hi, page, totalPages := f.paginate(r, len(matched))
+	return http.StatusOK, &models.Orders{Models: append([]*models.Order{}, matched[lo:hi]...), CurrentPage: page, TotalPages: totalPages}
+}
+
+func (f *FakeExchange) editOrder(r *fakeRequest) (int, interface{}) {
+	o := f.order(r.id(0))
+	if o == nil {
+		return notFound()
+	}
+	var body struct {
+		Order struct {
+			Quantity *models.Decimal `json:"quantity"`
+			Price    *models.Decimal `json:"price"`
+		} `json:"order"`
+	}
+	if err := r.decode(&body); err != nil {
+		return badRequest(err)
+	}
+	if !resting(o) {
+		return unprocessable("order", "not_live")
+	}
+	if q := body.Order.Quantity; q != nil {
+		if !q.GreaterThan(o.FilledQuantity) {
+			return unprocessable("quantity", "invalid")
+		}
+		o.Quantity = *q
+	}
+	if p := body.Order.Price; p != nil {
+		if p.Sign() <= 0 {
+			return unprocessable("price", "must_be_positive")
+		}
+		if !p.Equal(o.Price) {
+			// a new price loses time priority
+			f.seq++
+			f.priority[o.ID] = f.seq
+		}
+		o.Price = *p
+	}
+	o.UpdatedAt = f.now()
+	f.match(o, nil)
+	return http.StatusOK, o
+}
+
+func (f *FakeExchange) cancelOrder(r *fakeRequest) (int, interface{}) {
+	o := f.order(r.id(0))
+	if o == nil {
+		return notFound()
+	}
+	if o.Status != models.OrderStatusLive {
+		return unprocessable("order", "not_live")
+	}
+	o.Status = models.OrderStatusCancelled
+	o.UpdatedAt = f.now()
+	return http.StatusOK, o
+}
+
+func (f *FakeExchange) getOrderTrades(r *fakeRequest) (int, interface{}) {
+	if f.order(r.id(0)) == nil {
+		return notFound()
+	}
+	return http.StatusOK, []*models.Trade{}
+}
+
+// getExecutions lists executions newest first, or with a timestamp, the
+// executions from that second on in ascending order.
+func (f *FakeExchange) getExecutions(r *fakeRequest) (int, interface{}) {
+	productID, _ := strconv.Atoi(r.query("product_id"))
+	if ts := r.query("timestamp"); ts != "" {
+		sec, err := strconv.ParseInt(ts, 10, 64)
+		if err != nil {
+			return unprocessable("timestamp", "invalid")
+		}
+		limit, _ := strconv.Atoi(r.query("limit"))
+		if limit < 1 {
+			limit = f.PageSize
+		}
+		executions := []*models.ExecutionsModels{}
+		for _, e := range f.executions {
+			if e.productID == productID && e.CreatedAt.Unix() >= sec && len(executions) < limit {
+				executions = append(executions, f.publicExecution(e))
+			}
+		}
+		return http.StatusOK, executions
+	}
+	return f.pageExecutions(r, productID, f.publicExecution)
+}
+
+func (f *FakeExchange) getOwnExecutions(r *fakeRequest) (int, interface{}) {
+	productID, _ := strconv.Atoi(r.query("product_id"))
+	return f.pageExecutions(r, productID, func(e *fakeExecution) *models.ExecutionsModels {
+		cp := e.ExecutionsModels
+		return &cp
+	})
+}
+
+func (f *FakeExchange) publicExecution(e *fakeExecution) *models.ExecutionsModels {
+	cp := e.ExecutionsModels
+	cp.MySide = ""
+	return &cp
+}
+
+func (f *FakeExchange) pageExecutions(r *fakeRequest, productID int, view func(*fakeExecution) *models.ExecutionsModels) (int, interface{}) {
+	var matched []*models.ExecutionsModels
+	for i := len(f.executions) - 1; i >= 0; i-- {
+		if e := f.executions[i]; productID == 0 || e.productID == productID {
+			matched = append(matched, view(e))
+		}
+	}
+	lo, hi, page, totalPages := f.paginate(r, len(matched))
+	return http.StatusOK, &models.Executions{Models: append([]*models.ExecutionsModels{}, matched[lo:hi]...), CurrentPage: page, TotalPages: totalPages}
+}
+
+func (f *FakeExchange) getFiatAccounts(r *fakeRequest) (int, interface{}) {
+	return http.StatusOK, append([]*models.Account{}, f.fiatAccounts...)
+}
+
+func (f *FakeExchange) createFiatAccount(r *fakeRequest) (int, interface{}) {
+	var body struct {
+		Currency string `json:"currency"`
+	}
+	if err := r.decode(&body); err != nil {
+		return badRequest(err)
+	}
+	if body.Currency == "" {
+		return unprocessable("currency", "blank")
+	}
+	for _, a := range f.fiatAccounts {
+		if a.Currency == body.Currency {
+			return unprocessable("currency", "exists")
+		}
+	}
+	a := &models.Account{ID: f.id(), Currency: body.Currency, CurrencyType: "fiat"}
+	f.fiatAccounts = append(f.fiatAccounts, a)
+	return http.StatusOK, a
+}
+
+func (f *FakeExchange) getCryptoAccounts(r *fakeRequest) (int, interface{}) {
+	return http.StatusOK, append([]*models.CryptoAccount{}, f.cryptoAccounts...)
+}
+
+func (f *FakeExchange) getBalances(r *fakeRequest) (int, interface{}) {
+	balances := []*models.AccountBalance{}
+	for _, a := range f.fiatAccounts {
+		balances = append(balances, &models.AccountBalance{Currency: a.Currency, Balance: a.Balance})
+	}
+	for _, a := range f.cryptoAccounts {
+		balances = append(balances, &models.AccountBalance{Currency: a.Currency, Balance: a.Balance})
+	}
+	return http.StatusOK, balances
+}
+
+func (f *FakeExchange) tradingAccount(id int) *models.TradingAccount {
+	for _, a := range f.tradingAccounts {
+		if a.ID == id {
+			return a
+		}
+	}
+	return nil
+}
+
+func (f *FakeExchange) getTradingAccounts(r *fakeRequest) (int, interface{}) {
+	return http.StatusOK, append([]*models.TradingAccount{}, f.tradingAccounts...)
+}
+
+func (f *FakeExchange) getTradingAccount(r *fakeRequest) (int, interface{}) {
+	a := f.tradingAccount(r.id(0))
+	if a == nil {
+		return notFound()
+	}
+	return http.StatusOK, a
+}
+
+func (f *FakeExchange) editTradingAccount(r *fakeRequest) (int, interface{}) {
+	a := f.tradingAccount(r.id(0))
+	if a == nil {
+		return notFound()
+	}
+	var body struct {
+		TradingAccount struct {
+			LeverageLevel int `json:"leverage_level"`
+		} `json:"trading_account"`
+	}
+	if err := r.decode(&body); err != nil {
+		return badRequest(err)
+	}
+	level := body.TradingAccount.LeverageLevel
+	if level < 1 || (a.MaxLeverageLevel > 0 && level > a.MaxLeverageLevel) {
+		return unprocessable("leverage_level", "invalid")
+	}
+	a.LeverageLevel = level
+	a.UpdatedAt = f.now()
+	return http.StatusOK, a
+}
+
+func (f *FakeExchange) trade(id int) *models.Trade {
+	for _, tr := range f.trades {
+		if tr.ID == id {
+			return tr
+		}
+	}
+	return nil
+}
+
+func (f *FakeExchange) getTrades(r *fakeRequest) (int, interface{}) {
+	fundingCurrency := r.query("funding_currency")
+	status := models.TradeStatus(r.query("status"))
+	var matched []*models.Trade
+	for i := len(f.trades) - 1; i >= 0; i-- {
+		tr := f.trades[i]
+		if (fundingCurrency != "" && tr.FundingCurrency != fundingCurrency) || (status != "" && tr.Status != status) {
+			continue
+		}
+		matched = append(matched, tr)
+	}
+	lo, hi, page, totalPages := f.paginate(r, len(matched))
+	return http.StatusOK, &models.Trades{Models: append([]*models.Trade{}, matched[lo:hi]...), CurrentPage: page, TotalPages: totalPages}
+}
+
+// closeQuantity closes qty of an open trade; zero closes all of it.
+func (f *FakeExchange) closeQuantity(tr *models.Trade, qty models.Decimal) {
+	if qty.IsZero() || !qty.LessThan(tr.OpenQuantity) {
+		qty = tr.OpenQuantity
+	}
+	tr.OpenQuantity = tr.OpenQuantity.Sub(qty)
+	tr.CloseQuantity = tr.CloseQuantity.Add(qty)
+	if tr.OpenQuantity.Sign() <= 0 {
+		tr.Status = models.TradeStatusClosed
+	}
+	tr.UpdatedAt = f.now()
+}
+
+func (f *FakeExchange) closeTrade(r *fakeRequest) (int, interface{}) {
+	tr := f.trade(r.id(0))
+	if tr == nil {
+		return notFound()
+	}
+	var body struct {
+		ClosedQuantity models.Decimal `json:"closed_quantity"`
+	}
+	if err := r.decode(&body); err != nil {
+		return badRequest(err)
+	}
+	if tr.Status != models.TradeStatusOpen {
+		return unprocessable("trade", "not_open")
+	}
+	f.closeQuantity(tr, body.ClosedQuantity)
+	return http.StatusOK, tr
+}
+
+func (f *FakeExchange) closeAllTrades(r *fakeRequest) (int, interface{}) {
+	var body struct {
+		Side models.TradeSide `json:"side"`
+	}
+	if err := r.decode(&body); err != nil {
+		return badRequest(err)
+	}
+	closed := []*models.Trade{}
+	for _, tr := range f.trades {
+		if tr.Status == models.TradeStatusOpen && (body.Side == "" || tr.Side == body.Side) {
+			f.closeQuantity(tr, models.Decimal{})
+			closed = append(closed, tr)
+		}
+	}
+	return http.StatusOK, closed
+}
+
+func (f *FakeExchange) editTrade(r *fakeRequest) (int, interface{}) {
+	tr := f.trade(r.id(0))
+	if tr == nil {
+		return notFound()
+	}
+	var body struct {
+		Trade struct {
+			StopLoss   *models.Decimal `json:"stop_loss"`
+			TakeProfit *models.Decimal `json:"take_profit"`
+		} `json:"trade"`
+	}
+	if err := r.decode(&body); err != nil {
+		return badRequest(err)
+	}
+	if body.Trade.StopLoss != nil {
+		tr.StopLoss = *body.Trade.StopLoss
+	}
+	if body.Trade.TakeProfit != nil {
+		tr.TakeProfit = *body.Trade.TakeProfit
+	}
+	tr.UpdatedAt = f.now()
+	return http.StatusOK, tr
+}
+
+func (f *FakeExchange) getTradeLoans(r *fakeRequest) (int, interface{}) {
+	if f.trade(r.id(0)) == nil {
+		return notFound()
+	}
+	return http.StatusOK, []*models.Loan{}
+}
+
+func (f *FakeExchange) getLoans(r *fakeRequest) (int, interface{}) {
+	currency := r.query("currency")
+	var matched []*models.Loan
+	for i := len(f.loans) - 1; i >= 0; i-- {
+		if l := f.loans[i]; currency == "" || l.Currency == currency {
+			matched = append(matched, l)
+		}
+	}
+	lo, hi, page, totalPages := f.paginate(r, len(matched))
+	return http.StatusOK, &models.Loans{Models: append([]*models.Loan{}, matched[lo:hi]...), CurrentPage: page, TotalPages: totalPages}
+}
+
+func (f *FakeExchange) editLoan(r *fakeRequest) (int, interface{}) {
+	var loan *models.Loan
+	for _, l := range f.loans {
+		if l.ID == r.id(0) {
+			loan = l
+		}
+	}
+	if loan == nil {
+		return notFound()
+	}
+	var body struct {
+		Loan struct {
+			FundReloaned bool `json:"fund_reloaned"`
+		} `json:"loan"`
+	}
+	if err := r.decode(&body); err != nil {
+		return badRequest(err)
+	}
+	loan.FundReloaned = body.Loan.FundReloaned
+	return http.StatusOK, loan
+}
+
+func (f *FakeExchange) getLoanBids(r *fakeRequest) (int, interface{}) {
+	currency := r.query("currency")
+	var matched []*models.LoanBid
+	for i := len(f.loanBids) - 1; i >= 0; i-- {
+		if b := f.loanBids[i]; currency == "" || b.Currency == currency {
+			matched = append(matched, b)
+		}
+	}
+	lo, hi, page, totalPages := f.paginate(r, len(matched))
+	return http.StatusOK, &models.LoanBids{Models: append([]*models.LoanBid{}, matched[lo:hi]...), CurrentPage: page, TotalPages: totalPages}
+}
+
+func (f *FakeExchange) createLoanBid(r *fakeRequest) (int, interface{}) {
+	var body struct {
+		LoanBid struct {
+			Quantity models.Decimal `json:"quantity"`
+			Currency string         `json:"currency"`
+			Rate     models.Decimal `json:"rate"`
+		} `json:"loan_bid"`
+	}
+	if err := r.decode(&body); err != nil {
+		return badRequest(err)
+	}
+	req := body.LoanBid
+	switch {
+	case req.Currency == "":
+		return unprocessable("currency", "blank")
+	case req.Quantity.Sign() <= 0:
+		return unprocessable("quantity", "must_be_positive")
+	case req.Rate.Sign() <= 0:
+		return unprocessable("rate", "must_be_positive")
+	}
+	b := &models.LoanBid{ID: f.id(), BidaskType: "limit", Quantity: req.Quantity, Currency: req.Currency, Side: "bid",
+		Status: models.LoanBidStatusLive, Rate: req.Rate}
+	f.loanBids = append(f.loanBids, b)
+	return http.StatusOK, b
+}
+
+func (f *FakeExchange) closeLoanBid(r *fakeRequest) (int, interface{}) {
+	for _, b := range f.loanBids {
+		if b.ID != r.id(0) {
+			continue
+		}
+		if b.Status != models.LoanBidStatusLive {
+			return unprocessable("loan_bid", "not_live")
+		}
+		b.Status = models.LoanBidStatusClosed
+		return http.StatusOK, b
+	}
+	return notFound()
+}
+
+// getInterestRates builds the bid ladder from live loan bids.
+func (f *FakeExchange) getInterestRates(r *fakeRequest) (int, interface{}) {
+	rates := &models.InterestRates{Bids: [][]models.Decimal{}, Asks: [][]models.Decimal{}}
+	for _, b := range f.loanBids {
+		if b.Currency == r.param(0) && b.Status == models.LoanBidStatusLive {
+			rates.Bids = append(rates.Bids, []models.Decimal{b.Rate, b.Quantity.Sub(b.FilledQuantity)})
+		}
+	}
+	sort.SliceStable(rates.Bids, func(i, j int) bool { return rates.Bids[i][0].GreaterThan(rates.Bids[j][0]) })
+	return http.StatusOK, rates
+}
+
+// getCryptoWithdrawals lists withdrawals newest first.
+func (f *FakeExchange) getCryptoWithdrawals(r *fakeRequest) (int, interface{}) {
+	currency := r.query("currency")
+	state := models.WithdrawalState(r.query("state"))
+	var matched []*models.CryptoWithdrawal
+	for i := len(f.withdrawals) - 1; i >= 0; i-- {
+		w := f.withdrawals[i]
+		if (currency == "" || w.Currency == currency) && (state == "" || w.State == state) {
+			matched = append(matched, w)
+		}
+	}
+	lo, hi, page, totalPages := f.paginate(r, len(matched))
+	return http.StatusOK, &models.CryptoWithdrawals{Models: append([]*models.CryptoWithdrawal{}, matched[lo:hi]...), CurrentPage: page, TotalPages: totalPages}
+}
+
+// createCryptoWithdrawal holds the amount until the withdrawal is
+// cancelled; withdrawals stay pending.
+func (f *FakeExchange) createCryptoWithdrawal(r *fakeRequest) (int, interface{}) {
+	var body struct {
+		CryptoWithdrawal struct {
+			Currency  string         `json:"currency"`
+			Amount    models.Decimal `json:"amount"`
+			Address   string         `json:"address"`
+			PaymentID string         `json:"payment_id"`
+			Network   string         `json:"network"`
+		} `json:"crypto_withdrawal"`
+	}
+	if err := r.decode(&body); err != nil {
+		return badRequest(err)
+	}
+	req := body.CryptoWithdrawal
+	var account *models.CryptoAccount
+	for _, a := range f.cryptoAccounts {
+		if a.Currency == req.Currency {
+			account = a
+		}
+	}
+	switch {
+	case account == nil:
+		return unprocessable("currency", "invalid")
+	case req.Address == "":
+		return unprocessable("address", "blank")
+	case req.Amount.LessThan(account.MinimumWithdraw) || req.Amount.Sign() <= 0:
+		return unprocessable("amount", "below_minimum")
+	case req.Amount.GreaterThan(account.Balance):
+		return unprocessable("amount", "not_enough_free_balance")
+	}
+	account.Balance = account.Balance.Sub(req.Amount)
+	now := f.now()
+	w := &models.CryptoWithdrawal{ID: f.id(), Address: req.Address, Amount: req.Amount, Currency: req.Currency,
+		State: models.WithdrawalStatePending, PaymentID: req.PaymentID, Network: req.Network, CreatedAt: now, UpdatedAt: now}
+	f.withdrawals = append(f.withdrawals, w)
+	return http.StatusOK, w
+}
+
+func (f *FakeExchange) cancelCryptoWithdrawal(r *fakeRequest) (int, interface{}) {
+	for _, w := range f.withdrawals {
+		if w.ID != r.id(0) {
+			continue
+		}
+		if w.State != models.WithdrawalStatePending {
+			return unprocessable("crypto_withdrawal", "not_pending")
+		}
+		for _, a := range f.cryptoAccounts {
+			if a.Currency == w.Currency {
+				a.Balance = a.Balance.Add(w.Amount)
+			}
+		}
+		w.State = models.WithdrawalStateCancelled
+		w.UpdatedAt = f.now()
+		return http.StatusOK, w
+	}
+	return notFound()
+}
+
+func (f *FakeExchange) getWithdrawalAddresses(r *fakeRequest) (int, interface{}) {
+	currency := r.query("currency")
+	addresses := []*models.WithdrawalAddress{}
+	for _, a := range f.addresses {
+		if currency == "" || a.Currency == currency {
+			addresses = append(addresses, a)
+		}
+	}
+	return http.StatusOK, addresses
+}
+
+// getFiatDeposits lists deposits newest first.
+func (f *FakeExchange) getFiatDeposits(r *fakeRequest) (int, interface{}) {
+	currency := r.query("currency")
+	state := models.DepositState(r.query("state"))
+	var matched []*models.FiatDeposit
+	for i := len(f.fiatDeposits) - 1; i >= 0; i-- {
+		d := f.fiatDeposits[i]
+		if (currency == "" || d.Currency == currency) && (state == "" || d.State == state) {
+			matched = append(matched, d)
+		}
+	}
+	lo, hi, page, totalPages := f.paginate(r, len(matched))
+	return http.StatusOK, &models.FiatDeposits{Models: append([]*models.FiatDeposit{}, matched[lo:hi]...), CurrentPage: page, TotalPages: totalPages}
+}
+
+// getFiatWithdrawals lists withdrawals newest first.
+func (f *FakeExchange) getFiatWithdrawals(r *fakeRequest) (int, interface{}) {
+	currency := r.query("currency")
+	state := models.WithdrawalState(r.query("state"))
+	var matched []*models.FiatWithdrawal
+	for i := len(f.fiatWithdrawals) - 1; i >= 0; i-- {
+		w := f.fiatWithdrawals[i]
+		if (currency == "" || w.Currency == currency) && (state == "" || w.State == state) {
+			matched = append(matched, w)
+		}
+	}
+	lo, hi, page, totalPages := f.paginate(r, len(matched))
+	return http.StatusOK, &models.FiatWithdrawals{Models: append([]*models.FiatWithdrawal{}, matched[lo:hi]...), CurrentPage: page, TotalPages: totalPages}
+}
+
+func (f *FakeExchange) fiatAccount(currency string) *models.Account {
+	for _, a := range f.fiatAccounts {
+		if a.Currency == currency {
+			return a
+		}
+	}
+	return nil
+}
+
+// createFiatWithdrawal holds the amount until the withdrawal is cancelled;
+// withdrawals stay pending.
+func (f *FakeExchange) createFiatWithdrawal(r *fakeRequest) (int, interface{}) {
+	var body struct {
+		FiatWithdrawal struct {
+			Currency string         `json:"currency"`
+			Amount   models.Decimal `json:"amount"`
+			BankID   int            `json:"bank_id"`
+		} `json:"fiat_withdrawal"`
+	}
+	if err := r.decode(&body); err != nil {
+		return badRequest(err)
+	}
+	req := body.FiatWithdrawal
+	var bank *models.BankAccount
+	for _, b := range f.bankAccounts {
+		if b.ID == req.BankID {
+			bank = b
+		}
+	}
+	account := f.fiatAccount(req.Currency)
+	switch {
+	case account == nil:
+		return unprocessable("currency", "invalid")
+	case bank == nil || bank.Currency != req.Currency:
+		return unprocessable("bank_id", "invalid")
+	case req.Amount.Sign() <= 0:
+		return unprocessable("amount", "must_be_positive")
+	case req.Amount.GreaterThan(account.Balance):
+		return unprocessable("amount", "not_enough_free_balance")
+	}
+	account.Balance = account.Balance.Sub(req.Amount)
+	now := f.now()
+	w := &models.FiatWithdrawal{ID: f.id(), Currency: req.Currency, Amount: req.Amount, State: models.WithdrawalStatePending,
+		BankAccountID: req.BankID, CreatedAt: now, UpdatedAt: now}
+	f.fiatWithdrawals = append(f.fiatWithdrawals, w)
+	return http.StatusOK, w
+}
+
+func (f *FakeExchange) cancelFiatWithdrawal(r *fakeRequest) (int, interface{}) {
+	for _, w := range f.fiatWithdrawals {
+		if w.ID != r.id(0) {
+			continue
+		}
+		if w.State != models.WithdrawalStatePending {
+			return unprocessable("fiat_withdrawal", "not_pending")
+		}
+		if a := f.fiatAccount(w.Currency); a != nil {
+			a.Balance = a.Balance.Add(w.Amount)
+		}
+		w.State = models.WithdrawalStateCancelled
+		w.UpdatedAt = f.now()
+		return http.StatusOK, w
+	}
+	return notFound()
+}
+
+func (f *FakeExchange) getBankAccounts(r *fakeRequest) (int, interface{}) {
+	currency := r.query("currency")
+	var matched []*models.BankAccount
+	for _, a := range f.bankAccounts {
+		if currency == "" || a.Currency == currency {
+			matched = append(matched, a)
+		}
+	}
+	lo, hi, page, totalPages := f.paginate(r, len(matched))
+	return http.StatusOK, &models.BankAccounts{Models: append([]*models.BankAccount{}, matched[lo:hi]...), CurrentPage: page, TotalPages: totalPages}
+}
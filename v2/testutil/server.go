@@ -0,0 +1,315 @@
+package testutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/dgrijalva/jwt-go"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// reporter is the part of *testing.T the Server reports through.
+type reporter interface {
+	Errorf(format string, args ...interface{})
+}
+
+// Server is an httptest.Server scripted with expectations:
+//
+//	s := testutil.NewServer(t)
+//	defer s.Close()
+//	s.Expect("GET", "/orders/1").Respond(200, testutil.GetOrderJsonResponse())
+//	s.Expect("PUT", "/orders/1/cancel").Respond(200, testutil.GetCancelAnOrderJsonResponse())
+//	...
+//	if err := s.Verify(); err != nil {
+//		t.Error(err)
+//	}
+//
+// A request that matches no expectation, or matches one but fails its body,
+// header or JWT checks, fails t and is answered with 500.
+type Server struct {
+	*httptest.Server
+
+	t            reporter
+	mu           sync.Mutex
+	ordered      bool
+	next         int
+	expectations []*Expectation
+	problems     []string
+}
+
+func NewServer(t *testing.T) *Server {
+	return newServer(t)
+}
+
+func newServer(t reporter) *Server {
+	s := &Server{t: t}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// InOrder makes the expectations match only in the order they were added.
+func (s *Server) InOrder() *Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ordered = true
+	return s
+}
+
+// Expect adds an expectation for one call. A path with a query matches
+// the same parameters in any order; a path without one matches only
+// requests without a query.
+func (s *Server) Expect(method, path string) *Expectation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e := &Expectation{s: s, method: method, path: path, times: 1, status: http.StatusOK, response: "{}"}
+	if i := strings.Index(path, "?"); i >= 0 {
+		e.path = path[:i]
+		q, err := url.ParseQuery(path[i+1:])
+		if err != nil {
+			s.t.Errorf("Wrong expectation %s %s. %+v", method, path, err)
+		}
+		e.query = q
+	}
+	s.expectations = append(s.expectations, e)
+	return e
+}
+
+// Expectation is one scripted route. Its builder methods must be called
+// before the request they describe is sent.
+type Expectation struct {
+	s         *Server
+	method    string
+	path      string
+	query     url.Values
+	body      interface{}
+	hasBody   bool
+	headers   map[string]string
+	jwtSecret string
+	claims    map[string]interface{}
+	times     int
+	calls     int
+
+	status         int
+	response       string
+	responseHeader http.Header
+}
+
+// WithJSONBody requires a body equal to body as JSON values, so key order
+// and whitespace do not matter.
+func (e *Expectation) WithJSONBody(body string) *Expectation {
+	e.hasBody = true
+	if err := json.Unmarshal([]byte(body), &e.body); err != nil {
+		panic(fmt.Sprintf("testutil: invalid expected body %s: %v", body, err))
+	}
+	return e
+}
+
+func (e *Expectation) WithHeader(key, value string) *Expectation {
+	if e.headers == nil {
+		e.headers = map[string]string{}
+	}
+	e.headers[key] = value
+	return e
+}
+
+// WithJWT requires an X-Quoine-Auth token signed with secret whose path
+// claim is the request URI and whose other claims include claims.
+func (e *Expectation) WithJWT(secret string, claims map[string]interface{}) *Expectation {
+	e.jwtSecret = secret
+	e.claims = claims
+	return e
+}
+
+// Times sets how many calls are expected; -1 allows any number.
+func (e *Expectation) Times(n int) *Expectation {
+	e.times = n
+	return e
+}
+
+// Respond sets the status and JSON body of the response; without it the
+// expectation answers 200 {}.
+func (e *Expectation) Respond(status int, body string) *Expectation {
+	e.status = status
+	e.response = body
+	return e
+}
+
+func (e *Expectation) WithResponseHeader(key, value string) *Expectation {
+	if e.responseHeader == nil {
+		e.responseHeader = http.Header{}
+	}
+	e.responseHeader.Add(key, value)
+	return e
+}
+
+func (e *Expectation) String() string {
+	if len(e.query) > 0 {
+		return fmt.Sprintf("%s %s?%s", e.method, e.path, e.query.Encode())
+	}
+	return e.method + " " + e.path
+}
+
+func (e *Expectation) routeMatches(r *http.Request) bool {
+	if r.Method != e.method || r.URL.Path != e.path {
+		return false
+	}
+	if len(e.query) == 0 {
+		return r.URL.RawQuery == ""
+	}
+	return reflect.DeepEqual(r.URL.Query(), e.query)
+}
+
+func (e *Expectation) exhausted() bool {
+	return e.times >= 0 && e.calls >= e.times
+}
+
+func (e *Expectation) satisfied() bool {
+	return e.times < 0 || e.calls >= e.times
+}
+
+// check returns what the request gets wrong about the expectation.
+func (e *Expectation) check(r *http.Request, body []byte) []string {
+	var problems []string
+	if e.hasBody {
+		var actual interface{}
+		if err := json.Unmarshal(body, &actual); err != nil {
+			problems = append(problems, fmt.Sprintf("body is not JSON: %s", body))
+		} else if !reflect.DeepEqual(actual, e.body) {
+			expect, _ := json.Marshal(e.body)
+			problems = append(problems, fmt.Sprintf("body. actual: %s, expect: %s", body, expect))
+		}
+	}
+	for k, v := range e.headers {
+		if actual := r.Header.Get(k); actual != v {
+			problems = append(problems, fmt.Sprintf("header %s. actual: %q, expect: %q", k, actual, v))
+		}
+	}
+	if e.jwtSecret != "" {
+		problems = append(problems, e.checkJWT(r)...)
+	}
+	return problems
+}
+
+func (e *Expectation) checkJWT(r *http.Request) []string {
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(r.Header.Get("X-Quoine-Auth"), claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte(e.jwtSecret), nil
+	}); err != nil {
+		return []string{fmt.Sprintf("X-Quoine-Auth. %v", err)}
+	}
+	var problems []string
+	if claims["path"] != r.URL.RequestURI() {
+		problems = append(problems, fmt.Sprintf("path claim. actual: %v, expect: %s", claims["path"], r.URL.RequestURI()))
+	}
+	for k, v := range e.claims {
+		// compare as JSON values so that numbers match regardless of Go type
+		expect, _ := json.Marshal(v)
+		actual, _ := json.Marshal(claims[k])
+		if string(expect) != string(actual) {
+			problems = append(problems, fmt.Sprintf("%s claim. actual: %s, expect: %s", k, actual, expect))
+		}
+	}
+	return problems
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	r.Body.Close()
+	desc := r.Method + " " + r.URL.RequestURI()
+
+	s.mu.Lock()
+	e, problems := s.match(r, body)
+	if e == nil {
+		s.problems = append(s.problems, "unexpected request "+desc)
+	} else {
+		e.calls++
+		for _, p := range problems {
+			s.problems = append(s.problems, fmt.Sprintf("%s: wrong %s", desc, p))
+		}
+	}
+	s.mu.Unlock()
+
+	switch {
+	case e == nil:
+		s.t.Errorf("Wrong request. unexpected %s", desc)
+		http.Error(w, "unexpected request", http.StatusInternalServerError)
+		return
+	case len(problems) > 0:
+		for _, p := range problems {
+			s.t.Errorf("Wrong %s (%s)", p, desc)
+		}
+		http.Error(w, "request does not match expectation", http.StatusInternalServerError)
+		return
+	}
+	for k, v := range e.responseHeader {
+		w.Header()[k] = v
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(e.status)
+	fmt.Fprint(w, e.response)
+}
+
+// match picks the expectation for r: the next pending one in order, or
+// else the first matching route whose checks pass, falling back to the
+// first matching route so that its problems can be reported.
+func (s *Server) match(r *http.Request, body []byte) (*Expectation, []string) {
+	if s.ordered {
+		for i := s.next; i < len(s.expectations); i++ {
+			e := s.expectations[i]
+			if e.routeMatches(r) && !e.exhausted() {
+				s.next = i
+				return e, e.check(r, body)
+			}
+			if !e.satisfied() {
+				break
+			}
+		}
+		return nil, nil
+	}
+
+	var fallback *Expectation
+	var fallbackProblems []string
+	for _, e := range s.expectations {
+		if !e.routeMatches(r) || e.exhausted() {
+			continue
+		}
+		problems := e.check(r, body)
+		if len(problems) == 0 {
+			return e, nil
+		}
+		if fallback == nil {
+			fallback, fallbackProblems = e, problems
+		}
+	}
+	return fallback, fallbackProblems
+}
+
+// Calls returns how many requests matched the expectation.
+func (e *Expectation) Calls() int {
+	e.s.mu.Lock()
+	defer e.s.mu.Unlock()
+	return e.calls
+}
+
+// Verify reports expectations called fewer times than expected, together
+// with every unexpected or mismatched request.
+func (s *Server) Verify() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	problems := append([]string(nil), s.problems...)
+	for _, e := range s.expectations {
+		if !e.satisfied() {
+			problems = append(problems, fmt.Sprintf("%s called %d of %d times", e, e.calls, e.times))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("testutil: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package testutil
+
+import (
+	"fmt"
+	"github.com/dgrijalva/jwt-go"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type recordingReporter struct {
+	mu     sync.Mutex
+	errors []string
+}
+
+func (r *recordingReporter) Errorf(format string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+type testRequest struct {
+	method string
+	uri    string
+	body   string
+	secret string
+}
+
+func (tr testRequest) send(t *testing.T, baseURL string) *http.Response {
+	req, _ := http.NewRequest(tr.method, baseURL+tr.uri, strings.NewReader(tr.body))
+	if tr.secret != "" {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"path": tr.uri, "nonce": 1, "token_id": "apiTokenID"})
+		signed, _ := token.SignedString([]byte(tr.secret))
+		req.Header.Set("X-Quoine-Auth", signed)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Error. %+v", err)
+	}
+	res.Body.Close()
+	return res
+}
+
+func TestServer(t *testing.T) {
+	type Param struct {
+		setup    func(s *Server)
+		requests []testRequest
+	}
+	type Expect struct {
+		errors int
+		verify string
+	}
+	cases := []struct {
+		param  Param
+		expect Expect
+	}{
+		// test case 1: unordered
+		{param: Param{
+			setup: func(s *Server) {
+				s.Expect("GET", "/orders/1")
+				s.Expect("PUT", "/orders/1/cancel")
+			},
+			requests: []testRequest{{method: "PUT", uri: "/orders/1/cancel"}, {method: "GET", uri: "/orders/1"}},
+		}, expect: Expect{}},
+		// test case 2: ordered
+		{param: Param{
+			setup: func(s *Server) {
+				s.InOrder()
+				s.Expect("GET", "/orders/1")
+				s.Expect("PUT", "/orders/1/cancel")
+			},
+			requests: []testRequest{{method: "PUT", uri: "/orders/1/cancel"}, {method: "GET", uri: "/orders/1"}},
+		}, expect: Expect{errors: 1, verify: "unexpected request PUT /orders/1/cancel"}},
+		// test case 3: key order and whitespace do not matter
+		{param: Param{
+			setup: func(s *Server) {
+				s.Expect("PUT", "/orders/1").WithJSONBody(`{"order":{"quantity":"0.02","price":"520.0"}}`)
+			},
+			requests: []testRequest{{method: "PUT", uri: "/orders/1", body: `{ "order": {"price": "520.0", "quantity": "0.02"} }`}},
+		}, expect: Expect{}},
+		// test case 4
+		{param: Param{
+			setup: func(s *Server) {
+				s.Expect("PUT", "/orders/1").WithJSONBody(`{"order":{"quantity":"0.02"}}`)
+			},
+			requests: []testRequest{{method: "PUT", uri: "/orders/1", body: `{"order":{"quantity":"0.03"}}`}},
+		}, expect: Expect{errors: 1, verify: "wrong body"}},
+		// test case 5
+		{param: Param{
+			setup: func(s *Server) {
+				s.Expect("GET", "/products").Times(2)
+			},
+			requests: []testRequest{{method: "GET", uri: "/products"}},
+		}, expect: Expect{verify: "GET /products called 1 of 2 times"}},
+		// test case 6: query parameters in any order
+		{param: Param{
+			setup: func(s *Server) {
+				s.Expect("GET", "/orders?product_id=1&status=live")
+			},
+			requests: []testRequest{{method: "GET", uri: "/orders?status=live&product_id=1"}},
+		}, expect: Expect{}},
+		// test case 7
+		{param: Param{
+			setup: func(s *Server) {
+				s.Expect("GET", "/orders?product_id=1")
+			},
+			requests: []testRequest{{method: "GET", uri: "/orders"}},
+		}, expect: Expect{errors: 1, verify: "unexpected request GET /orders"}},
+		// test case 8
+		{param: Param{
+			setup: func(s *Server) {
+				s.Expect("GET", "/fiat_accounts").WithJWT("secret", map[string]interface{}{"token_id": "apiTokenID", "nonce": 1})
+			},
+			requests: []testRequest{{method: "GET", uri: "/fiat_accounts", secret: "secret"}},
+		}, expect: Expect{}},
+		// test case 9
+		{param: Param{
+			setup: func(s *Server) {
+				s.Expect("GET", "/fiat_accounts").WithJWT("secret", nil)
+			},
+			requests: []testRequest{{method: "GET", uri: "/fiat_accounts", secret: "wrong"}},
+		}, expect: Expect{errors: 1, verify: "wrong X-Quoine-Auth"}},
+		// test case 10
+		{param: Param{
+			setup: func(s *Server) {
+				s.Expect("GET", "/fiat_accounts").WithHeader("X-Quoine-API-Version", "2")
+			},
+			requests: []testRequest{{method: "GET", uri: "/fiat_accounts"}},
+		}, expect: Expect{errors: 1, verify: "wrong header X-Quoine-API-Version"}},
+	}
+	for i, c := range cases {
+		reporter := &recordingReporter{}
+		s := newServer(reporter)
+		c.param.setup(s)
+		for _, r := range c.param.requests {
+			r.send(t, s.URL)
+		}
+		s.Close()
+
+		if len(reporter.errors) != c.expect.errors {
+			t.Errorf("Wrong reported errors in case %d. %v", i+1, reporter.errors)
+		}
+		err := s.Verify()
+		if c.expect.verify == "" {
+			if err != nil {
+				t.Errorf("Wrong Verify in case %d. %+v", i+1, err)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), c.expect.verify) {
+			t.Errorf("Wrong Verify in case %d. actual: %v, expect: %s", i+1, err, c.expect.verify)
+		}
+	}
+}
+
+func TestServerResponse(t *testing.T) {
+	s := NewServer(t)
+	defer s.Close()
+	e := s.Expect("GET", "/products/1").Respond(429, `{"message":"slow down"}`).WithResponseHeader("Retry-After", "1").Times(-1)
+
+	for i := 0; i < 3; i++ {
+		res := testRequest{method: "GET", uri: "/products/1"}.send(t, s.URL)
+		if res.StatusCode != 429 || res.Header.Get("Retry-After") != "1" {
+			t.Errorf("Wrong response. status: %d, header: %v", res.StatusCode, res.Header)
+		}
+	}
+	if e.Calls() != 3 {
+		t.Errorf("Wrong calls. %d", e.Calls())
+	}
+	if err := s.Verify(); err != nil {
+		t.Errorf("Error. %+v", err)
+	}
+}
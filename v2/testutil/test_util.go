@@ -1,7 +1,6 @@
 package testutil
 
 import (
-	"encoding/json"
 	"fmt"
 	"github.com/sho3imo/quoinex-go-client/v2/models"
 	"io/ioutil"
@@ -40,6 +39,23 @@ func GenerateTestServer(t *testing.T, expectPath string, expectMethod string, ex
 	))
 }
 
+func GenerateErrorTestServer(t *testing.T, expectPath string, expectMethod string, statusCode int, jsonResponse string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.RequestURI() != expectPath {
+				t.Errorf("worng URL. actual:%+v, expect:%+v", r.URL.RequestURI(), expectPath)
+			}
+			if r.Method != expectMethod {
+				t.Errorf("worng Method. actual:%+v, expect:%+v", r.Method, expectMethod)
+			}
+
+			w.Header().Set("content-Type", "application/json")
+			w.WriteHeader(statusCode)
+			fmt.Fprint(w, jsonResponse)
+		},
+	))
+}
+
 func GetOrderJsonResponse() string {
 	return `{
   	"id": 2157479,
@@ -136,14 +152,14 @@ func GetExpectedOrderModel() *models.Order {
 	return &models.Order{
 		ID:                   2157479,
 		OrderType:            "limit",
-		Quantity:             "0.01",
-		DiscQuantity:         "0.0",
-		IcebergTotalQuantity: "0.0",
+		Quantity:             models.MustDecimal("0.01"),
+		DiscQuantity:         models.MustDecimal("0.0"),
+		IcebergTotalQuantity: models.MustDecimal("0.0"),
 		Side:                 "sell",
-		FilledQuantity:       "0.01",
-		Price:                "500.0",
-		CreatedAt:            1462123639,
-		UpdatedAt:            1462123639,
+		FilledQuantity:       models.MustDecimal("0.01"),
+		Price:                models.MustDecimal("500.0"),
+		CreatedAt:            models.NewTimestampFromUnix(1462123639),
+		UpdatedAt:            models.NewTimestampFromUnix(1462123639),
 		Status:               "filled",
 		LeverageLevel:        2,
 		SourceExchange:       "QUOINE",
@@ -151,15 +167,15 @@ func GetExpectedOrderModel() *models.Order {
 		ProductCode:          "CASH",
 		FundingCurrency:      "USD",
 		CurrencyPairCode:     "BTCUSD",
-		OrderFee:             "0.0",
+		OrderFee:             models.MustDecimal("0.0"),
 		Executions: models.OrderExecutions{
 			{
 				ID:        4566133,
-				Quantity:  "0.01",
-				Price:     "500.0",
+				Quantity:  models.MustDecimal("0.01"),
+				Price:     models.MustDecimal("500.0"),
 				TakerSide: "buy",
 				MySide:    "sell",
-				CreatedAt: 1465396785,
+				CreatedAt: models.NewTimestampFromUnix(1465396785),
 			},
 		},
 	}
@@ -171,25 +187,25 @@ func GetExpectedProductsModel() []*models.Product {
 		ProductType:         "CurrencyPair",
 		Code:                "CASH",
 		Name:                "CASH Trading",
-		MarketAsk:           "48203.05",
-		MarketBid:           "48188.15",
+		MarketAsk:           models.MustDecimal("48203.05"),
+		MarketBid:           models.MustDecimal("48188.15"),
 		Indicator:           -1,
 		Currency:            "JPY",
 		CurrencyPairCode:    "BTCJPY",
 		Symbol:              "¥",
-		FiatMinimumWithdraw: "1500.0",
+		FiatMinimumWithdraw: models.MustDecimal("1500.0"),
 		PusherChannel:       "product_cash_btcjpy_5",
-		TakerFee:            "0.0",
-		MakerFee:            "0.0",
-		LowMarketBid:        "47630.99",
-		HighMarketAsk:       "48396.71",
-		Volume24H:           "2915.627366519999999998",
-		LastPrice24H:        "48217.2",
-		LastTradedPrice:     "48203.05",
-		LastTradedQuantity:  "1.0",
+		TakerFee:            models.MustDecimal("0.0"),
+		MakerFee:            models.MustDecimal("0.0"),
+		LowMarketBid:        models.MustDecimal("47630.99"),
+		HighMarketAsk:       models.MustDecimal("48396.71"),
+		Volume24H:           models.MustDecimal("2915.627366519999999998"),
+		LastPrice24H:        models.MustDecimal("48217.2"),
+		LastTradedPrice:     models.MustDecimal("48203.05"),
+		LastTradedQuantity:  models.MustDecimal("1.0"),
 		QuotedCurrency:      "JPY",
 		BaseCurrency:        "BTC",
-		ExchangeRate:        "0.009398151671149725",
+		ExchangeRate:        models.MustDecimal("0.009398151671149725"),
 	}
 	return []*models.Product{model}
 }
@@ -200,25 +216,25 @@ func GetExpectedProductmodel() *models.Product {
 		ProductType:         "CurrencyPair",
 		Code:                "CASH",
 		Name:                "CASH Trading",
-		MarketAsk:           "48203.05",
-		MarketBid:           "48188.15",
+		MarketAsk:           models.MustDecimal("48203.05"),
+		MarketBid:           models.MustDecimal("48188.15"),
 		Indicator:           -1,
 		Currency:            "JPY",
 		CurrencyPairCode:    "BTCJPY",
 		Symbol:              "¥",
-		FiatMinimumWithdraw: "1500.0",
+		FiatMinimumWithdraw: models.MustDecimal("1500.0"),
 		PusherChannel:       "product_cash_btcjpy_5",
-		TakerFee:            "0.0",
-		MakerFee:            "0.0",
-		LowMarketBid:        "47630.99",
-		HighMarketAsk:       "48396.71",
-		Volume24H:           "2915.627366519999999998",
-		LastPrice24H:        "48217.2",
-		LastTradedPrice:     "48203.05",
-		LastTradedQuantity:  "1.0",
+		TakerFee:            models.MustDecimal("0.0"),
+		MakerFee:            models.MustDecimal("0.0"),
+		LowMarketBid:        models.MustDecimal("47630.99"),
+		HighMarketAsk:       models.MustDecimal("48396.71"),
+		Volume24H:           models.MustDecimal("2915.627366519999999998"),
+		LastPrice24H:        models.MustDecimal("48217.2"),
+		LastTradedPrice:     models.MustDecimal("48203.05"),
+		LastTradedQuantity:  models.MustDecimal("1.0"),
 		QuotedCurrency:      "JPY",
 		BaseCurrency:        "BTC",
-		ExchangeRate:        "0.009398151671149725",
+		ExchangeRate:        models.MustDecimal("0.009398151671149725"),
 	}
 }
 
@@ -234,8 +250,8 @@ func GetOrderBookJsonResponse() string {
 }
 
 func GetExpectedOrderBookModel() *models.PriceLevels {
-	buyPriceLevels := [][]json.Number{{"416.23000", "1.75000"}, {"0", "0"}}
-	sellPriceLevels := [][]json.Number{{"416.47000", "0.28675"}, {"1", "1"}}
+	buyPriceLevels := [][]models.Decimal{{models.MustDecimal("416.23000"), models.MustDecimal("1.75000")}, {models.MustDecimal("0"), models.MustDecimal("0")}}
+	sellPriceLevels := [][]models.Decimal{{models.MustDecimal("416.47000"), models.MustDecimal("0.28675")}, {models.MustDecimal("1"), models.MustDecimal("1")}}
 
 	return &models.PriceLevels{BuyPriceLevels: buyPriceLevels, SellPriceLevels: sellPriceLevels}
 }
@@ -264,8 +280,8 @@ func GetExecutionsJsonResponse() string {
 }
 
 func GetExpectedExecutionsModel() *models.Executions {
-	model1 := &models.ExecutionsModels{ID: 1011880, Quantity: "6.118954", Price: "409.78", TakerSide: "sell", CreatedAt: 1457370745}
-	model2 := &models.ExecutionsModels{ID: 1011791, Quantity: "1.15", Price: "409.12", TakerSide: "sell", CreatedAt: 1457365585}
+	model1 := &models.ExecutionsModels{ID: 1011880, Quantity: models.MustDecimal("6.118954"), Price: models.MustDecimal("409.78"), TakerSide: "sell", CreatedAt: models.NewTimestampFromUnix(1457370745)}
+	model2 := &models.ExecutionsModels{ID: 1011791, Quantity: models.MustDecimal("1.15"), Price: models.MustDecimal("409.12"), TakerSide: "sell", CreatedAt: models.NewTimestampFromUnix(1457365585)}
 	return &models.Executions{Models: []*models.ExecutionsModels{model1, model2}, CurrentPage: 2, TotalPages: 1686}
 }
 
@@ -289,8 +305,8 @@ func GetExecutionsByTimestampJsonResponse() string {
 }
 
 func GetExpectedExecutionsByTimestampModel() []*models.ExecutionsModels {
-	model1 := &models.ExecutionsModels{ID: 960598, Quantity: "5.6", Price: "431.89", TakerSide: "buy", CreatedAt: 1456705487}
-	model2 := &models.ExecutionsModels{ID: 960603, Quantity: "0.06", Price: "431.74", TakerSide: "buy", CreatedAt: 1456705564}
+	model1 := &models.ExecutionsModels{ID: 960598, Quantity: models.MustDecimal("5.6"), Price: models.MustDecimal("431.89"), TakerSide: "buy", CreatedAt: models.NewTimestampFromUnix(1456705487)}
+	model2 := &models.ExecutionsModels{ID: 960603, Quantity: models.MustDecimal("0.06"), Price: models.MustDecimal("431.74"), TakerSide: "buy", CreatedAt: models.NewTimestampFromUnix(1456705564)}
 
 	return []*models.ExecutionsModels{model1, model2}
 }
@@ -316,8 +332,8 @@ func GetInterestRatesJsonResponse() string {
 }
 
 func GetExpectedInterestRatesModel() *models.InterestRates {
-	bids := [][]string{{"0.00020", "23617.81698"}, {"0.00040", "50050.42000"}, {"0.00050", "100000.00000"}}
-	return &models.InterestRates{Bids: bids, Asks: []interface{}{}}
+	bids := [][]models.Decimal{{models.MustDecimal("0.00020"), models.MustDecimal("23617.81698")}, {models.MustDecimal("0.00040"), models.MustDecimal("50050.42000")}, {models.MustDecimal("0.00050"), models.MustDecimal("100000.00000")}}
+	return &models.InterestRates{Bids: bids, Asks: [][]models.Decimal{}}
 }
 
 func GetCreateAnOrderJsonResponse() string {
@@ -344,30 +360,21 @@ func GetCreateAnOrderJsonResponse() string {
 }
 
 func GetExpectedCreateAnOrderRequestBody() string {
-	return `{
-			"order": {
-				"order_type":"limit",
-				"product_id":1,
-				"side":"sell",
-				"quantity":"0.01",
-				"price":"500.0",
-				"price_range":""
-			}
-		}`
+	return `{"order":{"order_type":"limit","product_id":1,"side":"sell","quantity":"0.01","price":"500.0"}}`
 }
 
 func GetExpectedCreateAnOrderModel() *models.Order {
 	return &models.Order{
 		ID:                   2157474,
 		OrderType:            "limit",
-		Quantity:             "0.01",
-		DiscQuantity:         "0.0",
-		IcebergTotalQuantity: "0.0",
+		Quantity:             models.MustDecimal("0.01"),
+		DiscQuantity:         models.MustDecimal("0.0"),
+		IcebergTotalQuantity: models.MustDecimal("0.0"),
 		Side:                 "sell",
-		FilledQuantity:       "0.0",
-		Price:                "500.0",
-		CreatedAt:            1462123639,
-		UpdatedAt:            1462123639,
+		FilledQuantity:       models.MustDecimal("0.0"),
+		Price:                models.MustDecimal("500.0"),
+		CreatedAt:            models.NewTimestampFromUnix(1462123639),
+		UpdatedAt:            models.NewTimestampFromUnix(1462123639),
 		Status:               "live",
 		LeverageLevel:        1,
 		SourceExchange:       "QUOINE",
@@ -375,7 +382,7 @@ func GetExpectedCreateAnOrderModel() *models.Order {
 		ProductCode:          "CASH",
 		FundingCurrency:      "USD",
 		CurrencyPairCode:     "BTCUSD",
-		OrderFee:             "0.0",
+		OrderFee:             models.MustDecimal("0.0"),
 	}
 }
 
@@ -413,14 +420,14 @@ func GetExpectedOrdersModel() *models.Orders {
 	model1 := &models.Order{
 		ID:                   2157474,
 		OrderType:            "limit",
-		Quantity:             "0.01",
-		DiscQuantity:         "0.0",
-		IcebergTotalQuantity: "0.0",
+		Quantity:             models.MustDecimal("0.01"),
+		DiscQuantity:         models.MustDecimal("0.0"),
+		IcebergTotalQuantity: models.MustDecimal("0.0"),
 		Side:                 "sell",
-		FilledQuantity:       "0.0",
-		Price:                "500.0",
-		CreatedAt:            1462123639,
-		UpdatedAt:            1462123639,
+		FilledQuantity:       models.MustDecimal("0.0"),
+		Price:                models.MustDecimal("500.0"),
+		CreatedAt:            models.NewTimestampFromUnix(1462123639),
+		UpdatedAt:            models.NewTimestampFromUnix(1462123639),
 		Status:               "live",
 		LeverageLevel:        1,
 		SourceExchange:       "QUOINE",
@@ -428,7 +435,7 @@ func GetExpectedOrdersModel() *models.Orders {
 		ProductCode:          "CASH",
 		FundingCurrency:      "USD",
 		CurrencyPairCode:     "BTCUSD",
-		OrderFee:             "0.0",
+		OrderFee:             models.MustDecimal("0.0"),
 		Executions:           models.OrderExecutions{},
 	}
 	return &models.Orders{Models: []*models.Order{model1}, CurrentPage: 1, TotalPages: 1}
@@ -460,14 +467,14 @@ func GetExpectedCancelAnOrderModel() *models.Order {
 	return &models.Order{
 		ID:                   2157474,
 		OrderType:            "limit",
-		Quantity:             "0.01",
-		DiscQuantity:         "0.0",
-		IcebergTotalQuantity: "0.0",
+		Quantity:             models.MustDecimal("0.01"),
+		DiscQuantity:         models.MustDecimal("0.0"),
+		IcebergTotalQuantity: models.MustDecimal("0.0"),
 		Side:                 "sell",
-		FilledQuantity:       "0.0",
-		Price:                "500.0",
-		CreatedAt:            1462123639,
-		UpdatedAt:            1462123639,
+		FilledQuantity:       models.MustDecimal("0.0"),
+		Price:                models.MustDecimal("500.0"),
+		CreatedAt:            models.NewTimestampFromUnix(1462123639),
+		UpdatedAt:            models.NewTimestampFromUnix(1462123639),
 		Status:               "cancelled",
 		LeverageLevel:        1,
 		SourceExchange:       "QUOINE",
@@ -501,26 +508,21 @@ func GetEditALiveOrderJsonResponse() string {
 }
 
 func GetExpectedEditALiveOrderRequestBody() string {
-	return `{
-			"order": {
-				"quantity":"0.02",
-				"price":"520.0",
-			}
-		}`
+	return `{"order":{"quantity":"0.02","price":"520.0"}}`
 }
 
 func GetExpectedEditALiveOrderModel() *models.Order {
 	return &models.Order{
 		ID:                   2157474,
 		OrderType:            "limit",
-		Quantity:             "0.02",
-		DiscQuantity:         "0.0",
-		IcebergTotalQuantity: "0.0",
+		Quantity:             models.MustDecimal("0.02"),
+		DiscQuantity:         models.MustDecimal("0.0"),
+		IcebergTotalQuantity: models.MustDecimal("0.0"),
 		Side:                 "sell",
-		FilledQuantity:       "0.0",
-		Price:                "520.0",
-		CreatedAt:            1462123639,
-		UpdatedAt:            1462123639,
+		FilledQuantity:       models.MustDecimal("0.0"),
+		Price:                models.MustDecimal("520.0"),
+		CreatedAt:            models.NewTimestampFromUnix(1462123639),
+		UpdatedAt:            models.NewTimestampFromUnix(1462123639),
 		Status:               "live",
 		LeverageLevel:        1,
 		SourceExchange:       "QUOINE",
@@ -570,27 +572,27 @@ func GetExpectedOrderTradesModel() []*models.Trade {
 		CurrencyPairCode: "BTCUSD",
 		Status:           "closed",
 		Side:             "short",
-		MarginUsed:       "0.83588",
-		OpenQuantity:     "0.01",
-		CloseQuantity:    "0.0",
-		Quantity:         "0.01",
+		MarginUsed:       models.MustDecimal("0.83588"),
+		OpenQuantity:     models.MustDecimal("0.01"),
+		CloseQuantity:    models.MustDecimal("0.0"),
+		Quantity:         models.MustDecimal("0.01"),
 		LeverageLevel:    5,
 		ProductCode:      "CASH",
 		ProductID:        1,
-		OpenPrice:        "417.65",
-		ClosePrice:       "417.0",
+		OpenPrice:        models.MustDecimal("417.65"),
+		ClosePrice:       models.MustDecimal("417.0"),
 		TraderID:         3020,
-		OpenPnl:          "0.0",
-		ClosePnl:         "0.0065",
-		Pnl:              "0.0065",
-		StopLoss:         "0.0",
-		TakeProfit:       "0.0",
+		OpenPnl:          models.MustDecimal("0.0"),
+		ClosePnl:         models.MustDecimal("0.0065"),
+		Pnl:              models.MustDecimal("0.0065"),
+		StopLoss:         models.MustDecimal("0.0"),
+		TakeProfit:       models.MustDecimal("0.0"),
 		FundingCurrency:  "USD",
-		CreatedAt:        1456250726,
-		UpdatedAt:        1456251837,
-		CloseFee:         "0.0",
-		TotalInterest:    "0.02",
-		DailyInterest:    "0.02",
+		CreatedAt:        models.NewTimestampFromUnix(1456250726),
+		UpdatedAt:        models.NewTimestampFromUnix(1456251837),
+		CloseFee:         models.MustDecimal("0.0"),
+		TotalInterest:    models.MustDecimal("0.02"),
+		DailyInterest:    models.MustDecimal("0.02"),
 	}
 	return []*models.Trade{m1}
 }
@@ -613,7 +615,7 @@ func GetOwnExecutionsJsonResponse() string {
 }
 
 func GetExpectedOwnExecutionsModel() *models.Executions {
-	model1 := &models.ExecutionsModels{ID: 1001232, Quantity: "0.37153179", Price: "390.0", TakerSide: "sell", MySide: "sell", CreatedAt: 1457193798}
+	model1 := &models.ExecutionsModels{ID: 1001232, Quantity: models.MustDecimal("0.37153179"), Price: models.MustDecimal("390.0"), TakerSide: "sell", MySide: "sell", CreatedAt: models.NewTimestampFromUnix(1457193798)}
 	return &models.Executions{Models: []*models.ExecutionsModels{model1}, CurrentPage: 1, TotalPages: 2}
 }
 
@@ -638,11 +640,11 @@ func GetExpectedFiatAccountsModel() []*models.Account {
 		ID:                       4695,
 		Currency:                 "USD",
 		CurrencySymbol:           "$",
-		Balance:                  "10000.1773",
+		Balance:                  models.MustDecimal("10000.1773"),
 		PusherChannel:            "user_3020_account_usd",
-		LowestOfferInterestRate:  "0.00020",
-		HighestOfferInterestRate: "0.00060",
-		ExchangeRate:             "1.0",
+		LowestOfferInterestRate:  models.MustDecimal("0.00020"),
+		HighestOfferInterestRate: models.MustDecimal("0.00060"),
+		ExchangeRate:             models.MustDecimal("1.0"),
 		CurrencyType:             "fiat",
 	}
 	return []*models.Account{m1}
@@ -667,11 +669,11 @@ func GetExpectedCreateFiatAccountModel() *models.Account {
 		ID:                       5595,
 		Currency:                 "USD",
 		CurrencySymbol:           "$",
-		Balance:                  "0.0",
+		Balance:                  models.MustDecimal("0.0"),
 		PusherChannel:            "user_3122_account_usd",
-		LowestOfferInterestRate:  "0.00020",
-		HighestOfferInterestRate: "0.00060",
-		ExchangeRate:             "1.0",
+		LowestOfferInterestRate:  models.MustDecimal("0.00020"),
+		HighestOfferInterestRate: models.MustDecimal("0.00060"),
+		ExchangeRate:             models.MustDecimal("1.0"),
 		CurrencyType:             "fiat",
 	}
 }
@@ -696,14 +698,14 @@ func GetCryptoAccountsJsonResponse() string {
 func GetExpectedCryptoAccountsModel() []*models.CryptoAccount {
 	m1 := &models.CryptoAccount{
 		ID:                       4668,
-		Balance:                  "4.99",
+		Balance:                  models.MustDecimal("4.99"),
 		Address:                  "1F25zWAQ1BAAmppNxLV3KtK6aTNhxNg5Hg",
 		Currency:                 "BTC",
 		CurrencySymbol:           "฿",
 		PusherChannel:            "user_3020_account_btc",
-		MinimumWithdraw:          0.02,
-		LowestOfferInterestRate:  "0.00049",
-		HighestOfferInterestRate: "0.05000",
+		MinimumWithdraw:          models.MustDecimal("0.02"),
+		LowestOfferInterestRate:  models.MustDecimal("0.00049"),
+		HighestOfferInterestRate: models.MustDecimal("0.05000"),
 		CurrencyType:             "crypto",
 	}
 	return []*models.CryptoAccount{m1}
@@ -727,9 +729,9 @@ func GetAllAccountBalancesJsonResponse() string {
 }
 
 func GetExpectedAllAccountBalancesModel() []*models.AccountBalance {
-	m1 := &models.AccountBalance{Currency: "BTC", Balance: "0.04925688"}
-	m2 := &models.AccountBalance{Currency: "USD", Balance: "7.17696"}
-	m3 := &models.AccountBalance{Currency: "JPY", Balance: "356.01377"}
+	m1 := &models.AccountBalance{Currency: "BTC", Balance: models.MustDecimal("0.04925688")}
+	m2 := &models.AccountBalance{Currency: "USD", Balance: models.MustDecimal("7.17696")}
+	m3 := &models.AccountBalance{Currency: "JPY", Balance: models.MustDecimal("356.01377")}
 	return []*models.AccountBalance{m1, m2, m3}
 }
 
@@ -748,25 +750,19 @@ func GetCreateLoanBidJsonResponse() string {
 }
 
 func GetExpectedCreateALoanBidRequestBody() string {
-	return `{
-			"loan_bid": {
-				"quantity":"50",
-				"currency":"USD",
-				"rate":"0.0002"
-			}
-		}`
+	return `{"loan_bid":{"quantity":"50","currency":"USD","rate":"0.0002"}}`
 }
 
 func GetExpectedCreateLoanBidModel() *models.LoanBid {
 	return &models.LoanBid{
 		ID:             3580,
 		BidaskType:     "limit",
-		Quantity:       "50.0",
+		Quantity:       models.MustDecimal("50.0"),
 		Currency:       "USD",
 		Side:           "bid",
-		FilledQuantity: "0.0",
+		FilledQuantity: models.MustDecimal("0.0"),
 		Status:         "live",
-		Rate:           "0.0002",
+		Rate:           models.MustDecimal("0.0002"),
 		UserID:         3020,
 	}
 }
@@ -795,12 +791,12 @@ func GetExpectedLoanBidsModel() *models.LoanBids {
 	m1 := &models.LoanBid{
 		ID:             3580,
 		BidaskType:     "limit",
-		Quantity:       "50.0",
+		Quantity:       models.MustDecimal("50.0"),
 		Currency:       "USD",
 		Side:           "bid",
-		FilledQuantity: "0.0",
+		FilledQuantity: models.MustDecimal("0.0"),
 		Status:         "live",
-		Rate:           "0.0007",
+		Rate:           models.MustDecimal("0.0007"),
 		UserID:         3020,
 	}
 	return &models.LoanBids{Models: []*models.LoanBid{m1}, CurrentPage: 1, TotalPages: 1}
@@ -824,12 +820,12 @@ func GetExpectedCloseLoanBidModel() *models.LoanBid {
 	return &models.LoanBid{
 		ID:             3580,
 		BidaskType:     "limit",
-		Quantity:       "50.0",
+		Quantity:       models.MustDecimal("50.0"),
 		Currency:       "USD",
 		Side:           "bid",
-		FilledQuantity: "0.0",
+		FilledQuantity: models.MustDecimal("0.0"),
 		Status:         "closed",
-		Rate:           "0.0007",
+		Rate:           models.MustDecimal("0.0007"),
 		UserID:         3020,
 	}
 }
@@ -857,9 +853,9 @@ func GetLoansJsonResponse() string {
 func GetExpectedLoansModel() *models.Loans {
 	m1 := &models.Loan{
 		ID:           144825,
-		Quantity:     "495.1048",
-		Rate:         "0.0005",
-		CreatedAt:    1464168246,
+		Quantity:     models.MustDecimal("495.1048"),
+		Rate:         models.MustDecimal("0.0005"),
+		CreatedAt:    models.NewTimestampFromUnix(1464168246),
 		LenderID:     312,
 		BorrowerID:   5712,
 		Status:       "open",
@@ -884,19 +880,15 @@ func GetUpdateALoanJsonResponse() string {
 }
 
 func GetExpectedUpdateLoanBidRequestBody() string {
-	return `{
-			"loan": {
-				"fund_reloaned":false
-			}
-		}`
+	return `{"loan":{"fund_reloaned":false}}`
 }
 
 func GetExpectedUpdateALoanModel() *models.Loan {
 	return &models.Loan{
 		ID:           144825,
-		Quantity:     "495.1048",
-		Rate:         "0.0005",
-		CreatedAt:    1464168246,
+		Quantity:     models.MustDecimal("495.1048"),
+		Rate:         models.MustDecimal("0.0005"),
+		CreatedAt:    models.NewTimestampFromUnix(1464168246),
 		LenderID:     312,
 		BorrowerID:   5712,
 		Status:       "open",
@@ -936,20 +928,20 @@ func GetExpectedTradingAccounts() []*models.TradingAccount {
 		ID:               1759,
 		LeverageLevel:    10,
 		MaxLeverageLevel: 10,
-		Pnl:              "0.0",
-		Equity:           "10000.1773",
-		Margin:           "4.2302",
-		FreeMargin:       "9995.9471",
+		Pnl:              models.MustDecimal("0.0"),
+		Equity:           models.MustDecimal("10000.1773"),
+		Margin:           models.MustDecimal("4.2302"),
+		FreeMargin:       models.MustDecimal("9995.9471"),
 		TraderID:         4807,
 		Status:           "active",
 		ProductCode:      "CASH",
 		CurrencyPairCode: "BTCUSD",
-		Position:         "0.1",
-		Balance:          "10000.1773",
-		CreatedAt:        1421992165,
-		UpdatedAt:        1457242996,
+		Position:         models.MustDecimal("0.1"),
+		Balance:          models.MustDecimal("10000.1773"),
+		CreatedAt:        models.NewTimestampFromUnix(1421992165),
+		UpdatedAt:        models.NewTimestampFromUnix(1457242996),
 		PusherChannel:    "trading_account_1759",
-		MarginPercent:    "0.1",
+		MarginPercent:    models.MustDecimal("0.1"),
 		ProductID:        1,
 		FundingCurrency:  "USD",
 	}
@@ -985,20 +977,20 @@ func GetExpectedATradingAccount() *models.TradingAccount {
 		ID:               1759,
 		LeverageLevel:    10,
 		MaxLeverageLevel: 10,
-		Pnl:              "0.0",
-		Equity:           "10000.1773",
-		Margin:           "4.2302",
-		FreeMargin:       "9995.9471",
+		Pnl:              models.MustDecimal("0.0"),
+		Equity:           models.MustDecimal("10000.1773"),
+		Margin:           models.MustDecimal("4.2302"),
+		FreeMargin:       models.MustDecimal("9995.9471"),
 		TraderID:         4807,
 		Status:           "active",
 		ProductCode:      "CASH",
 		CurrencyPairCode: "BTCUSD",
-		Position:         "0.1",
-		Balance:          "10000.1773",
-		CreatedAt:        1421992165,
-		UpdatedAt:        1457242996,
+		Position:         models.MustDecimal("0.1"),
+		Balance:          models.MustDecimal("10000.1773"),
+		CreatedAt:        models.NewTimestampFromUnix(1421992165),
+		UpdatedAt:        models.NewTimestampFromUnix(1457242996),
 		PusherChannel:    "trading_account_1759",
-		MarginPercent:    "0.1",
+		MarginPercent:    models.MustDecimal("0.1"),
 		ProductID:        1,
 		FundingCurrency:  "USD",
 	}
@@ -1029,11 +1021,7 @@ func GetUpdateLeverageLevelJsonResponse() string {
 }
 
 func GetExpectedUpdateLeverageLevelRequestBody() string {
-	return `{
-			"trading_account": {
-				"leverage_level":25
-			}
-		}`
+	return `{"trading_account":{"leverage_level":25}}`
 }
 
 func GetExpectedUpdateLeverageLevel() *models.TradingAccount {
@@ -1041,20 +1029,20 @@ func GetExpectedUpdateLeverageLevel() *models.TradingAccount {
 		ID:               1759,
 		LeverageLevel:    25,
 		MaxLeverageLevel: 25,
-		Pnl:              "0.0",
-		Equity:           "10000.1773",
-		Margin:           "4.2302",
-		FreeMargin:       "9995.9471",
+		Pnl:              models.MustDecimal("0.0"),
+		Equity:           models.MustDecimal("10000.1773"),
+		Margin:           models.MustDecimal("4.2302"),
+		FreeMargin:       models.MustDecimal("9995.9471"),
 		TraderID:         4807,
 		Status:           "active",
 		ProductCode:      "CASH",
 		CurrencyPairCode: "BTCUSD",
-		Position:         "0.1",
-		Balance:          "10000.1773",
-		CreatedAt:        1421992165,
-		UpdatedAt:        1457242996,
+		Position:         models.MustDecimal("0.1"),
+		Balance:          models.MustDecimal("10000.1773"),
+		CreatedAt:        models.NewTimestampFromUnix(1421992165),
+		UpdatedAt:        models.NewTimestampFromUnix(1457242996),
 		PusherChannel:    "trading_account_1759",
-		MarginPercent:    "0.1",
+		MarginPercent:    models.MustDecimal("0.1"),
 		ProductID:        1,
 		FundingCurrency:  "USD",
 	}
@@ -1100,25 +1088,25 @@ func GetExpectedTradesModel() *models.Trades {
 		CurrencyPairCode: "BTCUSD",
 		Status:           "open",
 		Side:             "short",
-		MarginUsed:       "0.83588",
-		OpenQuantity:     "0.01",
-		CloseQuantity:    "0.0",
-		Quantity:         "0.01",
+		MarginUsed:       models.MustDecimal("0.83588"),
+		OpenQuantity:     models.MustDecimal("0.01"),
+		CloseQuantity:    models.MustDecimal("0.0"),
+		Quantity:         models.MustDecimal("0.01"),
 		LeverageLevel:    5,
 		ProductCode:      "CASH",
 		ProductID:        1,
-		OpenPrice:        "417.65",
-		ClosePrice:       "417.0",
+		OpenPrice:        models.MustDecimal("417.65"),
+		ClosePrice:       models.MustDecimal("417.0"),
 		TraderID:         3020,
-		OpenPnl:          "0.0",
-		ClosePnl:         "0.0",
-		Pnl:              "0.0065",
-		StopLoss:         "0.0",
-		TakeProfit:       "0.0",
+		OpenPnl:          models.MustDecimal("0.0"),
+		ClosePnl:         models.MustDecimal("0.0"),
+		Pnl:              models.MustDecimal("0.0065"),
+		StopLoss:         models.MustDecimal("0.0"),
+		TakeProfit:       models.MustDecimal("0.0"),
 		FundingCurrency:  "USD",
-		CreatedAt:        1456250726,
-		UpdatedAt:        1456251837,
-		TotalInterest:    "0.02",
+		CreatedAt:        models.NewTimestampFromUnix(1456250726),
+		UpdatedAt:        models.NewTimestampFromUnix(1456251837),
+		TotalInterest:    models.MustDecimal("0.02"),
 	}
 
 	return &models.Trades{Models: []*models.Trade{m1}, CurrentPage: 1, TotalPages: 1}
@@ -1153,7 +1141,7 @@ func GetCloseTradeJsonResponse() string {
 }
 
 func GetExpectedCloseTradeRequestBody() string {
-	return `{"closed_quantity":0.000100}`
+	return `{"closed_quantity":"0.0001"}`
 }
 
 func GetExpectedCloseTradeModel() *models.Trade {
@@ -1162,25 +1150,25 @@ func GetExpectedCloseTradeModel() *models.Trade {
 		CurrencyPairCode: "BTCUSD",
 		Status:           "closed",
 		Side:             "short",
-		MarginUsed:       "0.83588",
-		OpenQuantity:     "0.01",
-		CloseQuantity:    "0.0",
-		Quantity:         "0.01",
+		MarginUsed:       models.MustDecimal("0.83588"),
+		OpenQuantity:     models.MustDecimal("0.01"),
+		CloseQuantity:    models.MustDecimal("0.0"),
+		Quantity:         models.MustDecimal("0.01"),
 		LeverageLevel:    5,
 		ProductCode:      "CASH",
 		ProductID:        1,
-		OpenPrice:        "417.65",
-		ClosePrice:       "417.0",
+		OpenPrice:        models.MustDecimal("417.65"),
+		ClosePrice:       models.MustDecimal("417.0"),
 		TraderID:         3020,
-		OpenPnl:          "0.0",
-		ClosePnl:         "0.0065",
-		Pnl:              "0.0065",
-		StopLoss:         "0.0",
-		TakeProfit:       "0.0",
+		OpenPnl:          models.MustDecimal("0.0"),
+		ClosePnl:         models.MustDecimal("0.0065"),
+		Pnl:              models.MustDecimal("0.0065"),
+		StopLoss:         models.MustDecimal("0.0"),
+		TakeProfit:       models.MustDecimal("0.0"),
 		FundingCurrency:  "USD",
-		CreatedAt:        1456250726,
-		UpdatedAt:        1456251837,
-		TotalInterest:    "0.02",
+		CreatedAt:        models.NewTimestampFromUnix(1456250726),
+		UpdatedAt:        models.NewTimestampFromUnix(1456251837),
+		TotalInterest:    models.MustDecimal("0.02"),
 	}
 }
 
@@ -1220,25 +1208,25 @@ func GetExpectedCloseAllTradeModel() []*models.Trade {
 		CurrencyPairCode: "BTCUSD",
 		Status:           "closed",
 		Side:             "short",
-		MarginUsed:       "0.83588",
-		OpenQuantity:     "0.01",
-		CloseQuantity:    "0.0",
-		Quantity:         "0.01",
+		MarginUsed:       models.MustDecimal("0.83588"),
+		OpenQuantity:     models.MustDecimal("0.01"),
+		CloseQuantity:    models.MustDecimal("0.0"),
+		Quantity:         models.MustDecimal("0.01"),
 		LeverageLevel:    5,
 		ProductCode:      "CASH",
 		ProductID:        1,
-		OpenPrice:        "417.65",
-		ClosePrice:       "417.0",
+		OpenPrice:        models.MustDecimal("417.65"),
+		ClosePrice:       models.MustDecimal("417.0"),
 		TraderID:         3020,
-		OpenPnl:          "0.0",
-		ClosePnl:         "0.0065",
-		Pnl:              "0.0065",
-		StopLoss:         "0.0",
-		TakeProfit:       "0.0",
+		OpenPnl:          models.MustDecimal("0.0"),
+		ClosePnl:         models.MustDecimal("0.0065"),
+		Pnl:              models.MustDecimal("0.0065"),
+		StopLoss:         models.MustDecimal("0.0"),
+		TakeProfit:       models.MustDecimal("0.0"),
 		FundingCurrency:  "USD",
-		CreatedAt:        1456250726,
-		UpdatedAt:        1456251837,
-		TotalInterest:    "0.02",
+		CreatedAt:        models.NewTimestampFromUnix(1456250726),
+		UpdatedAt:        models.NewTimestampFromUnix(1456251837),
+		TotalInterest:    models.MustDecimal("0.02"),
 	}
 	return []*models.Trade{m1}
 }
@@ -1272,12 +1260,7 @@ func GetUpdateTradeJsonResponse() string {
 }
 
 func GetExpectedUpdateTradeRequestBody() string {
-	return `{
-			"trade": {
-				"stop_loss":"300",
-				"take_profit":"600"
-			}
-		}`
+	return `{"trade":{"stop_loss":"300","take_profit":"600"}}`
 }
 
 func GetExpectedUpdateTradeModel() *models.Trade {
@@ -1286,25 +1269,25 @@ func GetExpectedUpdateTradeModel() *models.Trade {
 		CurrencyPairCode: "BTCUSD",
 		Status:           "open",
 		Side:             "short",
-		MarginUsed:       "0.83588",
-		OpenQuantity:     "0.01",
-		CloseQuantity:    "0.0",
-		Quantity:         "0.01",
+		MarginUsed:       models.MustDecimal("0.83588"),
+		OpenQuantity:     models.MustDecimal("0.01"),
+		CloseQuantity:    models.MustDecimal("0.0"),
+		Quantity:         models.MustDecimal("0.01"),
 		LeverageLevel:    5,
 		ProductCode:      "CASH",
 		ProductID:        1,
-		OpenPrice:        "417.65",
-		ClosePrice:       "0",
+		OpenPrice:        models.MustDecimal("417.65"),
+		ClosePrice:       models.MustDecimal("0"),
 		TraderID:         3020,
-		OpenPnl:          "0.0",
-		ClosePnl:         "0.0065",
-		Pnl:              "0.0065",
-		StopLoss:         "300.0",
-		TakeProfit:       "600.0",
+		OpenPnl:          models.MustDecimal("0.0"),
+		ClosePnl:         models.MustDecimal("0.0065"),
+		Pnl:              models.MustDecimal("0.0065"),
+		StopLoss:         models.MustDecimal("300.0"),
+		TakeProfit:       models.MustDecimal("600.0"),
 		FundingCurrency:  "USD",
-		CreatedAt:        1456250726,
-		UpdatedAt:        1456251837,
-		TotalInterest:    "0.02",
+		CreatedAt:        models.NewTimestampFromUnix(1456250726),
+		UpdatedAt:        models.NewTimestampFromUnix(1456251837),
+		TotalInterest:    models.MustDecimal("0.02"),
 	}
 }
 
@@ -1327,9 +1310,9 @@ func GetTradesLoansJsonResponse() string {
 func GetExpectedTradesLoansModel() []*models.Loan {
 	m1 := &models.Loan{
 		ID:           103520,
-		Quantity:     "42.302",
-		Rate:         "0.0002",
-		CreatedAt:    1461998432,
+		Quantity:     models.MustDecimal("42.302"),
+		Rate:         models.MustDecimal("0.0002"),
+		CreatedAt:    models.NewTimestampFromUnix(1461998432),
 		LenderID:     100,
 		BorrowerID:   3020,
 		Status:       "open",
@@ -4,78 +4,104 @@ import (
 	"context"
 	"fmt"
 	"github.com/sho3imo/quoinex-go-client/v2/models"
-	"strings"
 )
 
-func (c *Client) GetTrades(ctx context.Context, fundingCurrency, status string) (*models.Trades, error) {
+func (c *Client) GetTrades(ctx context.Context, fundingCurrency string, status models.TradeStatus) (*models.Trades, error) {
+	if err := status.Validate(); err != nil {
+		return nil, err
+	}
 	spath := fmt.Sprintf("/trades")
 	queryParam := &map[string]string{
 		"funding_currency": fundingCurrency,
-		"status":           status}
-	res, err := c.sendRequest(ctx, "GET", spath, nil, queryParam)
-	if err != nil {
-		return nil, err
-	}
-
+		"status":           string(status)}
 	var trades models.Trades
-	if err := decodeBody(res, &trades); err != nil {
+	if err := c.sendRequest(ctx, "GetTrades", "GET", spath, nil, queryParam, &trades); err != nil {
 		return nil, err
 	}
 
 	return &trades, nil
 }
 
-func (c *Client) CloseTrade(ctx context.Context, tradeID int, closedQuantity float64) (*models.Trade, error) {
+// TradeFilter selects the trades walked by Trades. Zero fields are not sent.
+type TradeFilter struct {
+	FundingCurrency string
+	Status          models.TradeStatus
+	ListOptions
+}
+
+// TradeIterator walks every trade matching a filter, one page at a time.
+type TradeIterator = Iterator[*models.Trade]
+
+// NewTradeIterator returns an iterator that loads each page with fetch.
+func NewTradeIterator(ctx context.Context, opts ListOptions, fetch func(ctx context.Context, page int) (*models.Trades, error)) *TradeIterator {
+	return newPageIterator(ctx, opts, fetch, func(p *models.Trades) ([]*models.Trade, int) { return p.Models, p.TotalPages })
+}
+
+// Trades returns an iterator over every trade matching filter.
+func (c *Client) Trades(ctx context.Context, filter TradeFilter) *TradeIterator {
+	it := NewTradeIterator(ctx, filter.ListOptions, func(ctx context.Context, page int) (*models.Trades, error) {
+		queryParam := map[string]string{
+			"funding_currency": filter.FundingCurrency,
+			"status":           string(filter.Status)}
+		filter.setQuery(queryParam, page)
+		var trades models.Trades
+		if err := c.sendRequest(ctx, "GetTrades", "GET", "/trades", nil, &queryParam, &trades); err != nil {
+			return nil, err
+		}
+		return &trades, nil
+	})
+	if err := filter.Status.Validate(); err != nil {
+		it.stop(err)
+	}
+	return it
+}
+
+func (c *Client) CloseTrade(ctx context.Context, tradeID int, closedQuantity models.Decimal) (*models.Trade, error) {
 	spath := fmt.Sprintf("/trades/%d/close", tradeID)
-	bodyTemplate := `{"closed_quantity":%f}`
-	body := fmt.Sprintf(bodyTemplate, closedQuantity)
-	res, err := c.sendRequest(ctx, "PUT", spath, strings.NewReader(body), nil)
+	body, err := jsonBody(&closeTradeBody{ClosedQuantity: closedQuantity})
 	if err != nil {
 		return nil, err
 	}
-
 	var trade models.Trade
-	if err := decodeBody(res, &trade); err != nil {
+	if err := c.sendRequest(ctx, "CloseTrade", "PUT", spath, body, nil, &trade); err != nil {
 		return nil, err
 	}
 
 	return &trade, nil
 }
 
-func (c *Client) CloseAllTrade(ctx context.Context, side string) ([]*models.Trade, error) {
+func (c *Client) CloseAllTrade(ctx context.Context, side models.TradeSide) ([]*models.Trade, error) {
+	if err := side.Validate(); err != nil {
+		return nil, err
+	}
 	spath := fmt.Sprintf("/trades/close_all")
-	bodyTemplate := `{"side":"%s"}`
-	body := fmt.Sprintf(bodyTemplate, side)
-	res, err := c.sendRequest(ctx, "PUT", spath, strings.NewReader(body), nil)
+	body, err := jsonBody(&closeAllTradeBody{Side: side})
 	if err != nil {
 		return nil, err
 	}
-
 	var trades []*models.Trade
-	if err := decodeBody(res, &trades); err != nil {
+	if err := c.sendRequest(ctx, "CloseAllTrade", "PUT", spath, body, nil, &trades); err != nil {
 		return nil, err
 	}
 
 	return trades, nil
 }
 
-func (c *Client) UpdateTrade(ctx context.Context, tradeID, stopLoss, takeProfit int) (*models.Trade, error) {
+func (c *Client) UpdateTrade(ctx context.Context, tradeID int, stopLoss, takeProfit models.Decimal) (*models.Trade, error) {
+	return c.EditTrade(ctx, tradeID, &EditTradeRequest{StopLoss: optionalDecimal(stopLoss), TakeProfit: optionalDecimal(takeProfit)})
+}
+
+func (c *Client) EditTrade(ctx context.Context, tradeID int, req *EditTradeRequest) (*models.Trade, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request is nil")
+	}
 	spath := fmt.Sprintf("/trades/%d", tradeID)
-	bodyTemplate :=
-		`{
-			"trade": {
-				"stop_loss":"%d",
-				"take_profit":"%d"
-			}
-		}`
-	body := fmt.Sprintf(bodyTemplate, stopLoss, takeProfit)
-	res, err := c.sendRequest(ctx, "PUT", spath, strings.NewReader(body), nil)
+	body, err := jsonBody(&tradeEnvelope{Trade: req})
 	if err != nil {
 		return nil, err
 	}
-
 	var trade models.Trade
-	if err := decodeBody(res, &trade); err != nil {
+	if err := c.sendRequest(ctx, "UpdateTrade", "PUT", spath, body, nil, &trade); err != nil {
 		return nil, err
 	}
 
@@ -84,13 +110,8 @@ func (c *Client) UpdateTrade(ctx context.Context, tradeID, stopLoss, takeProfit
 
 func (c *Client) GetTradesLoans(ctx context.Context, tradeID int) ([]*models.Loan, error) {
 	spath := fmt.Sprintf("/trades/%d/loans", tradeID)
-	res, err := c.sendRequest(ctx, "GET", spath, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
 	var loans []*models.Loan
-	if err := decodeBody(res, &loans); err != nil {
+	if err := c.sendRequest(ctx, "GetTradesLoans", "GET", spath, nil, nil, &loans); err != nil {
 		return nil, err
 	}
 
@@ -12,7 +12,7 @@ import (
 func TestGetTrades(t *testing.T) {
 	type Param struct {
 		fundingCurrency string
-		status          string
+		status          models.TradeStatus
 		jsonResponse    string
 	}
 	type Expect struct {
@@ -36,8 +36,7 @@ func TestGetTrades(t *testing.T) {
 		ts := testutil.GenerateTestServer(t, c.expect.path, c.expect.method, c.expect.body, c.param.jsonResponse)
 		defer ts.Close()
 
-		client, _ := NewClient("apiTokenID", "secret", nil)
-		client.testServer = ts
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		trades, _ := client.GetTrades(ctx, c.param.fundingCurrency, c.param.status)
@@ -50,7 +49,7 @@ func TestGetTrades(t *testing.T) {
 func TestCloseTrade(t *testing.T) {
 	type Param struct {
 		tradeID        int
-		closedQuantity float64
+		closedQuantity models.Decimal
 		jsonResponse   string
 	}
 	type Expect struct {
@@ -65,7 +64,7 @@ func TestCloseTrade(t *testing.T) {
 	}{
 		// test case 1
 		{
-			param:  Param{tradeID: 57896, closedQuantity: 0.0001, jsonResponse: testutil.GetCloseTradeJsonResponse()},
+			param:  Param{tradeID: 57896, closedQuantity: models.MustDecimal("0.0001"), jsonResponse: testutil.GetCloseTradeJsonResponse()},
 			expect: Expect{path: "/trades/57896/close", method: "PUT", body: testutil.GetExpectedCloseTradeRequestBody(), trade: testutil.GetExpectedCloseTradeModel()},
 		},
 		// test case 2
@@ -75,8 +74,7 @@ func TestCloseTrade(t *testing.T) {
 		ts := testutil.GenerateTestServer(t, c.expect.path, c.expect.method, c.expect.body, c.param.jsonResponse)
 		defer ts.Close()
 
-		client, _ := NewClient("apiTokenID", "secret", nil)
-		client.testServer = ts
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		trade, _ := client.CloseTrade(ctx, c.param.tradeID, c.param.closedQuantity)
@@ -88,7 +86,7 @@ func TestCloseTrade(t *testing.T) {
 
 func TestCloseAllTrade(t *testing.T) {
 	type Param struct {
-		side         string
+		side         models.TradeSide
 		jsonResponse string
 	}
 	type Expect struct {
@@ -113,8 +111,7 @@ func TestCloseAllTrade(t *testing.T) {
 		ts := testutil.GenerateTestServer(t, c.expect.path, c.expect.method, c.expect.body, c.param.jsonResponse)
 		defer ts.Close()
 
-		client, _ := NewClient("apiTokenID", "secret", nil)
-		client.testServer = ts
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		trades, _ := client.CloseAllTrade(ctx, c.param.side)
@@ -127,8 +124,8 @@ func TestCloseAllTrade(t *testing.T) {
 func TestUpdateTrade(t *testing.T) {
 	type Param struct {
 		tradeID      int
-		stop_loss    int
-		take_profit  int
+		stop_loss    models.Decimal
+		take_profit  models.Decimal
 		jsonResponse string
 	}
 	type Expect struct {
@@ -143,7 +140,7 @@ func TestUpdateTrade(t *testing.T) {
 	}{
 		// test case 1
 		{
-			param:  Param{tradeID: 57897, stop_loss: 300, take_profit: 600, jsonResponse: testutil.GetUpdateTradeJsonResponse()},
+			param:  Param{tradeID: 57897, stop_loss: models.MustDecimal("300"), take_profit: models.MustDecimal("600"), jsonResponse: testutil.GetUpdateTradeJsonResponse()},
 			expect: Expect{path: "/trades/57897", method: "PUT", body: testutil.GetExpectedUpdateTradeRequestBody(), trade: testutil.GetExpectedUpdateTradeModel()},
 		},
 		// test case 2
@@ -153,8 +150,7 @@ func TestUpdateTrade(t *testing.T) {
 		ts := testutil.GenerateTestServer(t, c.expect.path, c.expect.method, c.expect.body, c.param.jsonResponse)
 		defer ts.Close()
 
-		client, _ := NewClient("apiTokenID", "secret", nil)
-		client.testServer = ts
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		trade, _ := client.UpdateTrade(ctx, c.param.tradeID, c.param.stop_loss, c.param.take_profit)
@@ -191,8 +187,7 @@ func TestGetTradesLoans(t *testing.T) {
 		ts := testutil.GenerateTestServer(t, c.expect.path, c.expect.method, c.expect.body, c.param.jsonResponse)
 		defer ts.Close()
 
-		client, _ := NewClient("apiTokenID", "secret", nil)
-		client.testServer = ts
+		client, _ := NewClient("apiTokenID", "secret", WithBaseURL(ts.URL))
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		loans, _ := client.GetTradesLoans(ctx, c.param.tradeID)
@@ -4,18 +4,12 @@ import (
 	"context"
 	"fmt"
 	"github.com/sho3imo/quoinex-go-client/v2/models"
-	"strings"
 )
 
 func (c *Client) GetTradingAccounts(ctx context.Context) ([]*models.TradingAccount, error) {
 	spath := fmt.Sprintf("/trading_accounts")
-	res, err := c.sendRequest(ctx, "GET", spath, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
 	var tradingAccounts []*models.TradingAccount
-	if err := decodeBody(res, &tradingAccounts); err != nil {
+	if err := c.sendRequest(ctx, "GetTradingAccounts", "GET", spath, nil, nil, &tradingAccounts); err != nil {
 		return nil, err
 	}
 
@@ -25,13 +19,8 @@ func (c *Client) GetTradingAccounts(ctx context.Context) ([]*models.TradingAccou
 func (c *Client) GetATradingAccount(ctx context.Context, tradingAccountID int) (*models.TradingAccount, error) {
 	spath := fmt.Sprintf("/trading_accounts/%d", tradingAccountID)
 
-	res, err := c.sendRequest(ctx, "GET", spath, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
 	var tradingAccount *models.TradingAccount
-	if err := decodeBody(res, &tradingAccount); err != nil {
+	if err := c.sendRequest(ctx, "GetATradingAccount", "GET", spath, nil, nil, &tradingAccount); err != nil {
 		return nil, err
 	}
 
@@ -39,21 +28,20 @@ func (c *Client) GetATradingAccount(ctx context.Context, tradingAccountID int) (
 }
 
 func (c *Client) UpdateLeverageLevel(ctx context.Context, tradeAccountID, leverageLevel int) (*models.TradingAccount, error) {
+	return c.EditTradingAccount(ctx, tradeAccountID, &EditTradingAccountRequest{LeverageLevel: leverageLevel})
+}
+
+func (c *Client) EditTradingAccount(ctx context.Context, tradeAccountID int, req *EditTradingAccountRequest) (*models.TradingAccount, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request is nil")
+	}
 	spath := fmt.Sprintf("/trading_accounts/%d", tradeAccountID)
-	bodyTemplate :=
-		`{
-			"trading_account": {
-				"leverage_level":%d
-			}
-		}`
-	body := fmt.Sprintf(bodyTemplate, leverageLevel)
-	res, err := c.sendRequest(ctx, "PUT", spath, strings.NewReader(body), nil)
+	body, err := jsonBody(&tradingAccountEnvelope{TradingAccount: req})
 	if err != nil {
 		return nil, err
 	}
-
 	var tradingAccount models.TradingAccount
-	if err := decodeBody(res, &tradingAccount); err != nil {
+	if err := c.sendRequest(ctx, "UpdateLeverageLevel", "PUT", spath, body, nil, &tradingAccount); err != nil {
 		return nil, err
 	}
 